@@ -0,0 +1,12 @@
+package features
+
+import (
+	"os"
+	"strings"
+)
+
+// EventGridSkipWaitingForDeletion returns whether the EventGrid resources should return as soon as their deletion
+// has been accepted, rather than waiting for it to complete - which is useful for best-effort teardowns
+func EventGridSkipWaitingForDeletion() bool {
+	return strings.EqualFold(os.Getenv("ARM_EVENTGRID_SKIP_DELETION_WAIT"), "true")
+}
@@ -0,0 +1,12 @@
+package features
+
+import (
+	"os"
+	"strings"
+)
+
+// EventGridStrictAdvancedFilters returns whether reading an EventGrid Event Subscription should fail when the API returns
+// an advanced filter operator which isn't supported by the provider, rather than logging a warning and ignoring it
+func EventGridStrictAdvancedFilters() bool {
+	return strings.EqualFold(os.Getenv("ARM_EVENTGRID_STRICT_ADVANCED_FILTERS"), "true")
+}
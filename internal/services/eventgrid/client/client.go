@@ -9,9 +9,14 @@ type Client struct {
 	DomainsClient                       *eventgrid.DomainsClient
 	DomainTopicsClient                  *eventgrid.DomainTopicsClient
 	EventSubscriptionsClient            *eventgrid.EventSubscriptionsClient
+	PartnerNamespacesClient             *eventgrid.PartnerNamespacesClient
+	PartnerRegistrationsClient          *eventgrid.PartnerRegistrationsClient
 	TopicsClient                        *eventgrid.TopicsClient
+	TopicTypesClient                    *eventgrid.TopicTypesClient
 	SystemTopicsClient                  *eventgrid.SystemTopicsClient
 	SystemTopicEventSubscriptionsClient *eventgrid.SystemTopicEventSubscriptionsClient
+
+	webhookFullURLs webhookFullURLCache
 }
 
 func NewClient(o *common.ClientOptions) *Client {
@@ -24,9 +29,18 @@ func NewClient(o *common.ClientOptions) *Client {
 	EventSubscriptionsClient := eventgrid.NewEventSubscriptionsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&EventSubscriptionsClient.Client, o.ResourceManagerAuthorizer)
 
+	PartnerNamespacesClient := eventgrid.NewPartnerNamespacesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&PartnerNamespacesClient.Client, o.ResourceManagerAuthorizer)
+
+	PartnerRegistrationsClient := eventgrid.NewPartnerRegistrationsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&PartnerRegistrationsClient.Client, o.ResourceManagerAuthorizer)
+
 	TopicsClient := eventgrid.NewTopicsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&TopicsClient.Client, o.ResourceManagerAuthorizer)
 
+	TopicTypesClient := eventgrid.NewTopicTypesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&TopicTypesClient.Client, o.ResourceManagerAuthorizer)
+
 	SystemTopicsClient := eventgrid.NewSystemTopicsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&SystemTopicsClient.Client, o.ResourceManagerAuthorizer)
 
@@ -37,8 +51,14 @@ func NewClient(o *common.ClientOptions) *Client {
 		DomainsClient:                       &DomainsClient,
 		EventSubscriptionsClient:            &EventSubscriptionsClient,
 		DomainTopicsClient:                  &DomainTopicsClient,
+		PartnerNamespacesClient:             &PartnerNamespacesClient,
+		PartnerRegistrationsClient:          &PartnerRegistrationsClient,
 		TopicsClient:                        &TopicsClient,
+		TopicTypesClient:                    &TopicTypesClient,
 		SystemTopicsClient:                  &SystemTopicsClient,
 		SystemTopicEventSubscriptionsClient: &SystemTopicEventSubscriptionsClient,
+		webhookFullURLs: webhookFullURLCache{
+			fullURLs: make(map[string]eventgrid.EventSubscriptionFullURL),
+		},
 	}
 }
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/eventgrid/mgmt/2020-10-15-preview/eventgrid"
+)
+
+// webhookFullURLCache holds the full urls of WebHook endpoints, which have to be retrieved using a separate API call
+// per Event Subscription. It's held on the Client so that it only lives as long as the provider instance it belongs to,
+// and entries are removed whenever the Event Subscription is created, updated or deleted.
+type webhookFullURLCache struct {
+	lock     sync.Mutex
+	fullURLs map[string]eventgrid.EventSubscriptionFullURL
+}
+
+// WebhookFullURL returns the cached full url of the WebHook endpoint for the Event Subscription with the specified
+// ID, calling getFullURL to retrieve (and cache) it when it isn't cached
+func (c *Client) WebhookFullURL(eventSubscriptionId string, getFullURL func() (eventgrid.EventSubscriptionFullURL, error)) (eventgrid.EventSubscriptionFullURL, error) {
+	cacheKey := strings.ToLower(eventSubscriptionId)
+
+	c.webhookFullURLs.lock.Lock()
+	existing, ok := c.webhookFullURLs.fullURLs[cacheKey]
+	c.webhookFullURLs.lock.Unlock()
+	if ok {
+		return existing, nil
+	}
+
+	fullURL, err := getFullURL()
+	if err != nil {
+		return fullURL, err
+	}
+
+	c.webhookFullURLs.lock.Lock()
+	if c.webhookFullURLs.fullURLs == nil {
+		c.webhookFullURLs.fullURLs = make(map[string]eventgrid.EventSubscriptionFullURL)
+	}
+	c.webhookFullURLs.fullURLs[cacheKey] = fullURL
+	c.webhookFullURLs.lock.Unlock()
+
+	return fullURL, nil
+}
+
+// RemoveWebhookFullURLFromCache removes the cached full url of the WebHook endpoint for the Event Subscription with
+// the specified ID, which needs to happen whenever the Event Subscription is created, updated or deleted
+func (c *Client) RemoveWebhookFullURLFromCache(eventSubscriptionId string) {
+	c.webhookFullURLs.lock.Lock()
+	delete(c.webhookFullURLs.fullURLs, strings.ToLower(eventSubscriptionId))
+	c.webhookFullURLs.lock.Unlock()
+}
@@ -2,14 +2,30 @@ package eventgrid
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"net/http"
+	"net/url"
 	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/preview/eventgrid/mgmt/2020-10-15-preview/eventgrid"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/eventgrid/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/eventgrid/validate"
+	msivalidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/msi/validate"
+	relayValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/relay/validate"
+	storageParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	storageValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/suppress"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
@@ -40,9 +56,32 @@ const (
 	WebHookEndpoint EventSubscriptionEndpointType = "webhook_endpoint"
 )
 
+var (
+	// ErrEventSubscriptionDeadLetterDestinationNotSpecified is returned when a `dead_letter_identity` is specified without a `storage_blob_dead_letter_destination`
+	ErrEventSubscriptionDeadLetterDestinationNotSpecified = errors.New("`dead_letter_identity`: `storage_blob_dead_letter_destination` must be specified")
+	// ErrEventSubscriptionUserAssignedIdentityNotSpecified is returned when a `UserAssigned` identity is specified without a `user_assigned_identity`
+	ErrEventSubscriptionUserAssignedIdentityNotSpecified = errors.New("`user_assigned_identity` must be specified when `type` is `UserAssigned`")
+)
+
+// EventSubscriptionEndpointNotSpecifiedError is returned when none of the endpoint types are specified for an Event Subscription
+type EventSubscriptionEndpointNotSpecifiedError struct {
+	// ResourceType is the human readable type of the Event Subscription, e.g. `EventGrid Event Subscription`
+	ResourceType string
+	// EndpointTypes are the endpoint types which can be specified for this type of Event Subscription
+	EndpointTypes []string
+}
+
+func (e EventSubscriptionEndpointNotSpecifiedError) Error() string {
+	return fmt.Sprintf("One of the following endpoint types must be specified to create an %s: %q", e.ResourceType, e.EndpointTypes)
+}
+
 func eventSubscriptionCustomizeDiffAdvancedFilter(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
 	if filterRaw := d.Get("advanced_filter"); len(filterRaw.([]interface{})) == 1 {
 		filters := filterRaw.([]interface{})[0].(map[string]interface{})
+		if err := validateEventGridEventSubscriptionAdvancedFilters(filters); err != nil {
+			return err
+		}
+
 		valueCount := 0
 		for _, valRaw := range filters {
 			for _, val := range valRaw.([]interface{}) {
@@ -61,6 +100,420 @@ func eventSubscriptionCustomizeDiffAdvancedFilter(ctx context.Context, d *plugin
 	return nil
 }
 
+// validateEventGridEventSubscriptionAdvancedFilters checks that each operator is only used once per key, and that no more
+// than 25 advanced filters are specified - this doesn't make any API calls so that it can also be checked during the plan
+func validateEventGridEventSubscriptionAdvancedFilters(config map[string]interface{}) error {
+	operatorTypes := make([]string, 0, len(config))
+	for operatorType := range config {
+		operatorTypes = append(operatorTypes, operatorType)
+	}
+	sort.Strings(operatorTypes)
+
+	filterCount := 0
+	seen := make(map[string]struct{})
+	duplicates := make([]string, 0)
+	for _, operatorType := range operatorTypes {
+		for _, raw := range config[operatorType].([]interface{}) {
+			filterCount++
+
+			// the key may be yet to be known during the plan
+			v, ok := raw.(map[string]interface{})
+			if !ok || v["key"].(string) == "" {
+				continue
+			}
+			key := v["key"].(string)
+
+			id := fmt.Sprintf("%s/%s", operatorType, key)
+			if _, exists := seen[id]; exists {
+				duplicates = append(duplicates, fmt.Sprintf("`%s` with the key %q", operatorType, key))
+				continue
+			}
+			seen[id] = struct{}{}
+		}
+	}
+
+	if len(duplicates) > 0 {
+		return fmt.Errorf("each `advanced_filter` operator can only be specified once for a given key, but found duplicates of %s", strings.Join(duplicates, ", "))
+	}
+
+	if filterCount > 25 {
+		return fmt.Errorf("the total number of `advanced_filter` filters allowed on a single event subscription is 25, but %d are configured", filterCount)
+	}
+
+	return nil
+}
+
+func eventSubscriptionCustomizeDiffDeadLetterIdentity(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	if v := d.Get("dead_letter_identity").([]interface{}); len(v) == 0 {
+		return nil
+	}
+
+	// the destination may reference a storage account which is yet to be created
+	if !d.NewValueKnown("storage_blob_dead_letter_destination") {
+		return nil
+	}
+
+	if v := d.Get("storage_blob_dead_letter_destination").([]interface{}); len(v) == 0 {
+		return ErrEventSubscriptionDeadLetterDestinationNotSpecified
+	}
+
+	return nil
+}
+
+func eventSubscriptionCustomizeDiffIdentity(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	for _, key := range []string{"delivery_identity", "dead_letter_identity"} {
+		v := d.Get(key).([]interface{})
+		if len(v) == 0 || v[0] == nil {
+			continue
+		}
+
+		// the user assigned identity may be yet to be created
+		if !d.NewValueKnown(fmt.Sprintf("%s.0.user_assigned_identity", key)) {
+			continue
+		}
+
+		if err := validateEventGridEventSubscriptionIdentity(v[0].(map[string]interface{})); err != nil {
+			return fmt.Errorf("`%s`: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// eventSubscriptionImportIDValidator returns a func which validates that an imported ID is of an Event Subscription
+// within one of the specified types of scope
+func eventSubscriptionImportIDValidator(scopeTypes ...parse.EventSubscriptionScopeType) func(id string) error {
+	return func(input string) error {
+		id, err := parse.EventSubscriptionScopedID(input)
+		if err != nil {
+			return err
+		}
+
+		supported := make([]string, 0)
+		for _, scopeType := range scopeTypes {
+			if id.ScopeType == scopeType {
+				return nil
+			}
+			supported = append(supported, string(scopeType))
+		}
+
+		return fmt.Errorf("expected an Event Subscription within a scope of type %s but %q is within a scope of type %s", strings.Join(supported, " or "), input, id.ScopeType)
+	}
+}
+
+// eventSubscriptionProvisioningStateFailed returns whether the Event Subscription has finished provisioning
+// without succeeding
+func eventSubscriptionProvisioningStateFailed(state eventgrid.EventSubscriptionProvisioningState) bool {
+	return state == eventgrid.EventSubscriptionProvisioningStateFailed || state == eventgrid.EventSubscriptionProvisioningStateCanceled
+}
+
+// eventSubscriptionIsBeingDeleted returns whether the error returned when creating an Event Subscription is a conflict
+// caused by a previous Event Subscription with the same name which is still being deleted
+func eventSubscriptionIsBeingDeleted(err error) bool {
+	var detailed autorest.DetailedError
+	if !errors.As(err, &detailed) || detailed.StatusCode != http.StatusConflict {
+		return false
+	}
+
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "being deleted") || strings.Contains(message, "deleting")
+}
+
+// eventSubscriptionReplicationTimeout is how long to wait for an Event Subscription to become readable once it's
+// been created, since the first read can return a 404 whilst the Event Subscription is being replicated
+const eventSubscriptionReplicationTimeout = 30 * time.Second
+
+// eventSubscriptionGetAfterCreate retrieves an Event Subscription which has just been created (or updated), retrying
+// for a short while if it isn't found yet rather than failing the apply for an Event Subscription which exists
+func eventSubscriptionGetAfterCreate(get func() (eventgrid.EventSubscription, error)) (eventgrid.EventSubscription, error) {
+	var read eventgrid.EventSubscription
+	err := pluginsdk.Retry(eventSubscriptionReplicationTimeout, func() *pluginsdk.RetryError {
+		var getErr error
+		read, getErr = get()
+		if getErr != nil {
+			if utils.ResponseWasNotFound(read.Response) {
+				log.Printf("[DEBUG] EventGrid Event Subscription wasn't found after being created - retrying")
+				return pluginsdk.RetryableError(getErr)
+			}
+
+			return pluginsdk.NonRetryableError(getErr)
+		}
+
+		return nil
+	})
+
+	return read, err
+}
+
+// eventSubscriptionSupportedDeliverySchemas are the event delivery schemas which events published using a given input
+// schema can be delivered with - events can be converted from the EventGrid schema to the CloudEvents schema but not the
+// other way around, and only events published using a custom schema can be delivered using the input schema
+var eventSubscriptionSupportedDeliverySchemas = map[string][]string{
+	string(eventgrid.InputSchemaEventGridSchema): {
+		string(eventgrid.EventGridSchema),
+		string(eventgrid.CloudEventSchemaV10),
+	},
+	string(eventgrid.InputSchemaCloudEventSchemaV10): {
+		string(eventgrid.CloudEventSchemaV10),
+	},
+	string(eventgrid.InputSchemaCustomEventSchema): {
+		string(eventgrid.EventGridSchema),
+		string(eventgrid.CloudEventSchemaV10),
+		string(eventgrid.CustomInputSchema),
+	},
+}
+
+func eventSubscriptionValidateDeliverySchemaForInputSchema(inputSchema string, deliverySchema string) error {
+	supportedSchemas, ok := eventSubscriptionSupportedDeliverySchemas[inputSchema]
+	if !ok {
+		return nil
+	}
+
+	if !utils.SliceContainsValue(supportedSchemas, deliverySchema) {
+		return fmt.Errorf("an `event_delivery_schema` of %q isn't supported for events published using the %q input schema - supported values are %s", deliverySchema, inputSchema, strings.Join(supportedSchemas, ", "))
+	}
+
+	return nil
+}
+
+// eventSubscriptionUnsupportedDeliverySchemas are the event delivery schemas which can't be used with a given endpoint type
+var eventSubscriptionUnsupportedDeliverySchemas = map[string][]string{
+	string(StorageQueueEndpoint): {
+		string(eventgrid.CloudEventSchemaV10),
+	},
+}
+
+func eventSubscriptionCustomizeDiffEventDeliverySchema(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	deliverySchema := d.Get("event_delivery_schema").(string)
+	for endpointType, unsupportedSchemas := range eventSubscriptionUnsupportedDeliverySchemas {
+		if v := d.Get(endpointType).([]interface{}); len(v) == 0 {
+			continue
+		}
+		if utils.SliceContainsValue(unsupportedSchemas, deliverySchema) {
+			return fmt.Errorf("an `event_delivery_schema` of %q isn't supported when using a `%s`", deliverySchema, endpointType)
+		}
+	}
+	return nil
+}
+
+// eventSubscriptionCustomizeDiffDeadLetterDeliverySchema warns when events are delivered as CloudEvents but are
+// dead-lettered to a Storage Blob, since the dead-lettered events aren't stored in the `event_delivery_schema` - this
+// isn't an error, but the payloads found in the dead-letter container differ from those delivered to the endpoint
+func eventSubscriptionCustomizeDiffDeadLetterDeliverySchema(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	if !d.HasChange("event_delivery_schema") && !d.HasChange("storage_blob_dead_letter_destination") {
+		return nil
+	}
+
+	if d.Get("event_delivery_schema").(string) != string(eventgrid.CloudEventSchemaV10) {
+		return nil
+	}
+
+	if v := d.Get("storage_blob_dead_letter_destination").([]interface{}); len(v) == 0 {
+		return nil
+	}
+
+	log.Printf("[WARN] EventGrid Event Subscription %q: events are delivered using the `%s` schema but dead-lettered events are stored in the `storage_blob_dead_letter_destination` in the EventGrid schema, so their payloads will differ from those delivered to the endpoint", d.Get("name").(string), eventgrid.CloudEventSchemaV10)
+	return nil
+}
+
+func eventSubscriptionCustomizeDiffDeliveryProperty(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	deliveryProperties := d.Get("delivery_property").([]interface{})
+	if len(deliveryProperties) > 0 && len(d.Get("storage_queue_endpoint").([]interface{})) > 0 {
+		// the service silently drops these for a Storage Queue, so they'd never be sent with the events
+		return fmt.Errorf("`delivery_property` isn't supported with a `storage_queue_endpoint`")
+	}
+
+	for _, v := range deliveryProperties {
+		if v == nil {
+			continue
+		}
+		mapping := v.(map[string]interface{})
+		if mapping["type"].(string) != "Static" || !isAuthorizationDeliveryProperty(mapping["header_name"].(string)) {
+			continue
+		}
+		if !mapping["secret"].(bool) {
+			return fmt.Errorf("a `Static` `delivery_property` for the `Authorization` header must have `secret` set to `true` so that its `value` is never returned by the API")
+		}
+	}
+	return nil
+}
+
+// eventSubscriptionCustomizeDiffExpirationRelativeToNow resolves `expiration_relative_to_now` into `expiration_time_utc`,
+// re-resolving it on each plan once the current expiration time has drifted outside of the tolerance window
+func eventSubscriptionCustomizeDiffExpirationRelativeToNow(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("expiration_relative_to_now") {
+		return d.SetNewComputed("expiration_time_utc")
+	}
+
+	current := d.Get("expiration_time_utc").(string)
+
+	relative := d.Get("expiration_relative_to_now").(string)
+	if relative == "" {
+		// `expiration_time_utc` is Computed so that it can be resolved from `expiration_relative_to_now`, as such
+		// removing it from the config needs to explicitly clear it rather than keeping the value in the state
+		if current != "" && eventSubscriptionConfigValueIsNull(d, "expiration_time_utc") {
+			return d.SetNew("expiration_time_utc", "")
+		}
+		return nil
+	}
+
+	duration, err := time.ParseDuration(relative)
+	if err != nil {
+		return fmt.Errorf("parsing `expiration_relative_to_now`: %+v", err)
+	}
+
+	now := time.Now().UTC()
+	if !eventSubscriptionExpirationNeedsRenewal(current, now, duration) {
+		return nil
+	}
+
+	return d.SetNew("expiration_time_utc", now.Add(duration).Format(time.RFC3339))
+}
+
+// eventSubscriptionExpirationTimeClockSkew is how far in the past `expiration_time_utc` can be, to allow for the clock
+// of the machine running Terraform differing from that of the service
+const eventSubscriptionExpirationTimeClockSkew = time.Minute
+
+// eventSubscriptionCustomizeDiffExpirationTimeUTC rejects an expiration time which is in the past, since the service
+// would immediately disable the Event Subscription. This is only checked when the value changes so that existing
+// Event Subscriptions which have since expired can still be planned
+func eventSubscriptionCustomizeDiffExpirationTimeUTC(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	if !d.HasChange("expiration_time_utc") || !d.NewValueKnown("expiration_time_utc") {
+		return nil
+	}
+
+	return validateEventGridExpirationTimeIsInFuture(d.Get("expiration_time_utc").(string), time.Now())
+}
+
+func validateEventGridExpirationTimeIsInFuture(input string, now time.Time) error {
+	if input == "" {
+		return nil
+	}
+
+	expirationTime, err := time.Parse(time.RFC3339, input)
+	if err != nil {
+		return fmt.Errorf("parsing `expiration_time_utc` %q: %+v", input, err)
+	}
+
+	if expirationTime.Before(now.Add(-eventSubscriptionExpirationTimeClockSkew)) {
+		return fmt.Errorf("`expiration_time_utc` must be in the future but %q is in the past (the current time is %s)", input, now.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// eventSubscriptionExpirationNeedsRenewal returns whether the current expiration time of an Event Subscription falls
+// outside of the tolerance window (a tenth of the duration) around the expiration time resolved from the duration
+func eventSubscriptionExpirationNeedsRenewal(current string, now time.Time, duration time.Duration) bool {
+	if current == "" {
+		return true
+	}
+
+	expirationTime, err := time.Parse(time.RFC3339, current)
+	if err != nil {
+		return true
+	}
+
+	tolerance := duration / 10
+	expected := now.Add(duration)
+	return expirationTime.Before(expected.Add(-tolerance)) || expirationTime.After(expected.Add(tolerance))
+}
+
+// eventSubscriptionConfigValueIsNull returns whether the top-level attribute is omitted from the config, which can't
+// be determined using Get for Optional and Computed attributes since that falls back to the value in the state
+func eventSubscriptionConfigValueIsNull(d *pluginsdk.ResourceDiff, key string) bool {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() || !config.Type().HasAttribute(key) {
+		return false
+	}
+
+	return config.GetAttr(key).IsNull()
+}
+
+// isAuthorizationDeliveryProperty returns whether the header carries credentials, in which case
+// its value is only ever sourced from the configuration and is never read back from the API
+func isAuthorizationDeliveryProperty(headerName string) bool {
+	return strings.EqualFold(headerName, "Authorization")
+}
+
+// eventSubscriptionConfigHashKeys returns the keys which `config_hash` is computed from, other than `delivery_property`
+func eventSubscriptionConfigHashKeys(endpointTypes []string) []string {
+	return append([]string{
+		"included_event_types",
+		"subject_filter",
+		"advanced_filter",
+		"advanced_filtering_on_arrays_enabled",
+		"retry_policy",
+	}, endpointTypes...)
+}
+
+// eventSubscriptionCustomizeDiffConfigHash marks `config_hash` as changing when any of the configuration it's computed
+// from changes, since it's otherwise only recomputed when the Event Subscription is read after being updated
+func eventSubscriptionCustomizeDiffConfigHash(endpointTypes []string, additionalKeys ...string) pluginsdk.CustomizeDiffFunc {
+	return func(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+		if d.Id() == "" {
+			return nil
+		}
+
+		for _, key := range append(eventSubscriptionConfigHashKeys(endpointTypes), additionalKeys...) {
+			if d.HasChange(key) {
+				return d.SetNewComputed("config_hash")
+			}
+		}
+		return nil
+	}
+}
+
+// eventSubscriptionConfigHash returns a stable hash of the destination, filter and retry configuration of an
+// Event Subscription, excluding any secrets, so that meaningful changes can be detected by external tooling
+func eventSubscriptionConfigHash(d *pluginsdk.ResourceData, endpointTypes []string) (string, error) {
+	config := make(map[string]interface{})
+	for _, key := range eventSubscriptionConfigHashKeys(endpointTypes) {
+		config[key] = d.Get(key)
+	}
+
+	if v, ok := config[string(WebHookEndpoint)].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		// the full url can contain secrets within the query string, so only the base url is used
+		webhook := make(map[string]interface{})
+		for k, val := range v[0].(map[string]interface{}) {
+			if k != "url" {
+				webhook[k] = val
+			}
+		}
+		config[string(WebHookEndpoint)] = []interface{}{webhook}
+	}
+
+	if raw, ok := d.GetOk("delivery_property"); ok {
+		deliveryProperties := make([]interface{}, 0)
+		for _, v := range raw.([]interface{}) {
+			if v == nil {
+				continue
+			}
+			deliveryProperty := make(map[string]interface{})
+			for k, val := range v.(map[string]interface{}) {
+				deliveryProperty[k] = val
+			}
+			if secret, ok := deliveryProperty["secret"].(bool); ok && secret {
+				delete(deliveryProperty, "value")
+			}
+			deliveryProperties = append(deliveryProperties, deliveryProperty)
+		}
+		config["delivery_property"] = deliveryProperties
+	}
+
+	return hashEventSubscriptionConfig(config)
+}
+
+func hashEventSubscriptionConfig(config map[string]interface{}) (string, error) {
+	// map keys are sorted when marshalled, so the output is deterministic
+	serialized, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("serializing configuration: %+v", err)
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(serialized)), nil
+}
+
 func eventSubscriptionSchemaEventSubscriptionName() *pluginsdk.Schema {
 	return &pluginsdk.Schema{
 		Type:     pluginsdk.TypeString,
@@ -77,10 +530,10 @@ func eventSubscriptionSchemaEventSubscriptionName() *pluginsdk.Schema {
 }
 
 func eventSubscriptionSchemaEventDeliverySchema() *pluginsdk.Schema {
+	// the delivery schema can be updated in-place, since it's sent in full when updating the Event Subscription
 	return &pluginsdk.Schema{
 		Type:     pluginsdk.TypeString,
 		Optional: true,
-		ForceNew: true,
 		Default:  string(eventgrid.EventGridSchema),
 		ValidateFunc: validation.StringInSlice([]string{
 			string(eventgrid.EventGridSchema),
@@ -133,57 +586,71 @@ func eventSubscriptionSchemaDeliveryProperty() *pluginsdk.Schema {
 
 func eventSubscriptionSchemaExpirationTimeUTC() *pluginsdk.Schema {
 	return &pluginsdk.Schema{
-		Type:         pluginsdk.TypeString,
-		Optional:     true,
-		ValidateFunc: validation.StringIsNotEmpty,
+		Type:     pluginsdk.TypeString,
+		Optional: true,
+		// Computed since this is resolved from `expiration_relative_to_now` when that's specified
+		Computed:      true,
+		ValidateFunc:  validation.IsRFC3339Time,
+		ConflictsWith: []string{"expiration_relative_to_now"},
 	}
 }
 
-func eventSubscriptionSchemaAzureFunctionEndpoint(conflictsWith []string) *pluginsdk.Schema {
+func eventSubscriptionSchemaExpirationRelativeToNow() *pluginsdk.Schema {
 	return &pluginsdk.Schema{
-		Type:          pluginsdk.TypeList,
-		MaxItems:      1,
+		Type:          pluginsdk.TypeString,
 		Optional:      true,
-		ConflictsWith: conflictsWith,
+		ValidateFunc:  validate.EventSubscriptionExpirationRelativeToNow,
+		ConflictsWith: []string{"expiration_time_utc"},
+	}
+}
+
+func eventSubscriptionSchemaAzureFunctionEndpoint(exactlyOneOf []string) *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:         pluginsdk.TypeList,
+		MaxItems:     1,
+		Optional:     true,
+		ExactlyOneOf: exactlyOneOf,
 		Elem: &pluginsdk.Resource{
 			Schema: map[string]*pluginsdk.Schema{
 				"function_id": {
 					Type:         pluginsdk.TypeString,
 					Required:     true,
-					ValidateFunc: azure.ValidateResourceID,
+					ValidateFunc: validate.AzureFunctionID,
 				},
 				"max_events_per_batch": {
-					Type:     pluginsdk.TypeInt,
-					Optional: true,
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntBetween(1, 5000),
 				},
 				"preferred_batch_size_in_kilobytes": {
-					Type:     pluginsdk.TypeInt,
-					Optional: true,
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntBetween(1, 1024),
 				},
 			},
 		},
 	}
 }
 
-func eventSubscriptionSchemaEventHubEndpointID(conflictsWith []string) *pluginsdk.Schema {
+func eventSubscriptionSchemaEventHubEndpointID(exactlyOneOf []string) *pluginsdk.Schema {
 	return &pluginsdk.Schema{
-		Type:          pluginsdk.TypeString,
-		Optional:      true,
-		Computed:      true,
-		ConflictsWith: conflictsWith,
-		ValidateFunc:  azure.ValidateResourceID,
+		Type:         pluginsdk.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: exactlyOneOf,
+		ValidateFunc: azure.ValidateResourceID,
 	}
 }
 
-func eventSubscriptionSchemaEventHubEndpoint(conflictsWith []string) *pluginsdk.Schema {
+func eventSubscriptionSchemaEventHubEndpoint(exactlyOneOf []string) *pluginsdk.Schema {
 	//lintignore:XS003
 	return &pluginsdk.Schema{
-		Type:          pluginsdk.TypeList,
-		MaxItems:      1,
-		Deprecated:    "Deprecated in favour of `" + "eventhub_endpoint_id" + "`",
-		Optional:      true,
-		Computed:      true,
-		ConflictsWith: conflictsWith,
+		Type:         pluginsdk.TypeList,
+		MaxItems:     1,
+		Deprecated:   "Deprecated in favour of `" + "eventhub_endpoint_id" + "`",
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: exactlyOneOf,
 		Elem: &pluginsdk.Resource{
 			Schema: map[string]*pluginsdk.Schema{
 				"eventhub_id": {
@@ -197,62 +664,62 @@ func eventSubscriptionSchemaEventHubEndpoint(conflictsWith []string) *pluginsdk.
 	}
 }
 
-func eventSubscriptionSchemaHybridConnectionEndpointID(conflictsWith []string) *pluginsdk.Schema {
+func eventSubscriptionSchemaHybridConnectionEndpointID(exactlyOneOf []string) *pluginsdk.Schema {
 	return &pluginsdk.Schema{
-		Type:          pluginsdk.TypeString,
-		Optional:      true,
-		Computed:      true,
-		ConflictsWith: conflictsWith,
-		ValidateFunc:  azure.ValidateResourceID,
+		Type:         pluginsdk.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: exactlyOneOf,
+		ValidateFunc: relayValidate.HybridConnectionID,
 	}
 }
 
-func eventSubscriptionSchemaHybridEndpoint(conflictsWith []string) *pluginsdk.Schema {
+func eventSubscriptionSchemaHybridEndpoint(exactlyOneOf []string) *pluginsdk.Schema {
 	//lintignore:XS003
 	return &pluginsdk.Schema{
-		Type:          pluginsdk.TypeList,
-		MaxItems:      1,
-		Deprecated:    "Deprecated in favour of `" + "hybrid_connection_endpoint_id" + "`",
-		Optional:      true,
-		Computed:      true,
-		ConflictsWith: conflictsWith,
+		Type:         pluginsdk.TypeList,
+		MaxItems:     1,
+		Deprecated:   "Deprecated in favour of `" + "hybrid_connection_endpoint_id" + "`",
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: exactlyOneOf,
 		Elem: &pluginsdk.Resource{
 			Schema: map[string]*pluginsdk.Schema{
 				"hybrid_connection_id": {
 					Type:         pluginsdk.TypeString,
 					Optional:     true,
 					Computed:     true,
-					ValidateFunc: azure.ValidateResourceID,
+					ValidateFunc: relayValidate.HybridConnectionID,
 				},
 			},
 		},
 	}
 }
 
-func eventSubscriptionSchemaServiceBusQueueEndpointID(conflictsWith []string) *pluginsdk.Schema {
+func eventSubscriptionSchemaServiceBusQueueEndpointID(exactlyOneOf []string) *pluginsdk.Schema {
 	return &pluginsdk.Schema{
-		Type:          pluginsdk.TypeString,
-		Optional:      true,
-		ConflictsWith: conflictsWith,
-		ValidateFunc:  azure.ValidateResourceID,
+		Type:         pluginsdk.TypeString,
+		Optional:     true,
+		ExactlyOneOf: exactlyOneOf,
+		ValidateFunc: azure.ValidateResourceID,
 	}
 }
 
-func eventSubscriptionSchemaServiceBusTopicEndpointID(conflictsWith []string) *pluginsdk.Schema {
+func eventSubscriptionSchemaServiceBusTopicEndpointID(exactlyOneOf []string) *pluginsdk.Schema {
 	return &pluginsdk.Schema{
-		Type:          pluginsdk.TypeString,
-		Optional:      true,
-		ConflictsWith: conflictsWith,
-		ValidateFunc:  azure.ValidateResourceID,
+		Type:         pluginsdk.TypeString,
+		Optional:     true,
+		ExactlyOneOf: exactlyOneOf,
+		ValidateFunc: azure.ValidateResourceID,
 	}
 }
 
-func eventSubscriptionSchemaStorageQueueEndpoint(conflictsWith []string) *pluginsdk.Schema {
+func eventSubscriptionSchemaStorageQueueEndpoint(exactlyOneOf []string) *pluginsdk.Schema {
 	return &pluginsdk.Schema{
-		Type:          pluginsdk.TypeList,
-		MaxItems:      1,
-		Optional:      true,
-		ConflictsWith: conflictsWith,
+		Type:         pluginsdk.TypeList,
+		MaxItems:     1,
+		Optional:     true,
+		ExactlyOneOf: exactlyOneOf,
 		Elem: &pluginsdk.Resource{
 			Schema: map[string]*pluginsdk.Schema{
 				"storage_account_id": {
@@ -274,17 +741,20 @@ func eventSubscriptionSchemaStorageQueueEndpoint(conflictsWith []string) *plugin
 	}
 }
 
-func eventSubscriptionSchemaWebHookEndpoint(conflictsWith []string) *pluginsdk.Schema {
+func eventSubscriptionSchemaWebHookEndpoint(exactlyOneOf []string) *pluginsdk.Schema {
 	return &pluginsdk.Schema{
-		Type:          pluginsdk.TypeList,
-		MaxItems:      1,
-		Optional:      true,
-		ConflictsWith: conflictsWith,
+		Type:         pluginsdk.TypeList,
+		MaxItems:     1,
+		Optional:     true,
+		ExactlyOneOf: exactlyOneOf,
 		Elem: &pluginsdk.Resource{
 			Schema: map[string]*pluginsdk.Schema{
+				// the full url can contain a secret in the query string (e.g. an Azure Function key), whereas
+				// `base_url` is returned without it and so can be safely shown in the plan
 				"url": {
 					Type:         pluginsdk.TypeString,
 					Required:     true,
+					Sensitive:    true,
 					ValidateFunc: validation.IsURLWithHTTPS,
 				},
 				"base_url": {
@@ -305,9 +775,15 @@ func eventSubscriptionSchemaWebHookEndpoint(conflictsWith []string) *pluginsdk.S
 					Type:     pluginsdk.TypeString,
 					Optional: true,
 				},
+				// the client secret used to acquire the token is managed on the Azure AD Application, so only the
+				// Application ID or Application ID URI is needed here
 				"active_directory_app_id_or_uri": {
 					Type:     pluginsdk.TypeString,
 					Optional: true,
+					ValidateFunc: validation.Any(
+						validation.IsUUID,
+						validation.IsURLWithScheme([]string{"api", "http", "https"}),
+					),
 				},
 			},
 		},
@@ -321,7 +797,7 @@ func eventSubscriptionSchemaIncludedEventTypes() *pluginsdk.Schema {
 		Computed: true,
 		Elem: &pluginsdk.Schema{
 			Type:         pluginsdk.TypeString,
-			ValidateFunc: validation.StringIsNotEmpty,
+			ValidateFunc: validate.EventSubscriptionIncludedEventType,
 		},
 	}
 }
@@ -345,11 +821,13 @@ func eventSubscriptionSchemaSubjectFilter() *pluginsdk.Schema {
 					Type:         pluginsdk.TypeString,
 					Optional:     true,
 					AtLeastOneOf: []string{"subject_filter.0.subject_begins_with", "subject_filter.0.subject_ends_with", "subject_filter.0.case_sensitive"},
+					ValidateFunc: validate.EventSubscriptionSubjectFilter,
 				},
 				"subject_ends_with": {
 					Type:         pluginsdk.TypeString,
 					Optional:     true,
 					AtLeastOneOf: []string{"subject_filter.0.subject_begins_with", "subject_filter.0.subject_ends_with", "subject_filter.0.case_sensitive"},
+					ValidateFunc: validate.EventSubscriptionSubjectFilter,
 				},
 				"case_sensitive": {
 					Type:         pluginsdk.TypeBool,
@@ -381,7 +859,7 @@ func eventSubscriptionSchemaAdvancedFilter() *pluginsdk.Schema {
 							"key": {
 								Type:         pluginsdk.TypeString,
 								Required:     true,
-								ValidateFunc: validation.StringIsNotEmpty,
+								ValidateFunc: validate.EventSubscriptionAdvancedFilterKey,
 							},
 							"value": {
 								Type:     pluginsdk.TypeBool,
@@ -399,7 +877,7 @@ func eventSubscriptionSchemaAdvancedFilter() *pluginsdk.Schema {
 							"key": {
 								Type:         pluginsdk.TypeString,
 								Required:     true,
-								ValidateFunc: validation.StringIsNotEmpty,
+								ValidateFunc: validate.EventSubscriptionAdvancedFilterKey,
 							},
 							"value": {
 								Type:     pluginsdk.TypeFloat,
@@ -417,7 +895,7 @@ func eventSubscriptionSchemaAdvancedFilter() *pluginsdk.Schema {
 							"key": {
 								Type:         pluginsdk.TypeString,
 								Required:     true,
-								ValidateFunc: validation.StringIsNotEmpty,
+								ValidateFunc: validate.EventSubscriptionAdvancedFilterKey,
 							},
 							"value": {
 								Type:     pluginsdk.TypeFloat,
@@ -435,7 +913,7 @@ func eventSubscriptionSchemaAdvancedFilter() *pluginsdk.Schema {
 							"key": {
 								Type:         pluginsdk.TypeString,
 								Required:     true,
-								ValidateFunc: validation.StringIsNotEmpty,
+								ValidateFunc: validate.EventSubscriptionAdvancedFilterKey,
 							},
 							"value": {
 								Type:     pluginsdk.TypeFloat,
@@ -453,7 +931,7 @@ func eventSubscriptionSchemaAdvancedFilter() *pluginsdk.Schema {
 							"key": {
 								Type:         pluginsdk.TypeString,
 								Required:     true,
-								ValidateFunc: validation.StringIsNotEmpty,
+								ValidateFunc: validate.EventSubscriptionAdvancedFilterKey,
 							},
 							"value": {
 								Type:     pluginsdk.TypeFloat,
@@ -471,7 +949,7 @@ func eventSubscriptionSchemaAdvancedFilter() *pluginsdk.Schema {
 							"key": {
 								Type:         pluginsdk.TypeString,
 								Required:     true,
-								ValidateFunc: validation.StringIsNotEmpty,
+								ValidateFunc: validate.EventSubscriptionAdvancedFilterKey,
 							},
 							"values": {
 								Type:     pluginsdk.TypeList,
@@ -493,7 +971,7 @@ func eventSubscriptionSchemaAdvancedFilter() *pluginsdk.Schema {
 							"key": {
 								Type:         pluginsdk.TypeString,
 								Required:     true,
-								ValidateFunc: validation.StringIsNotEmpty,
+								ValidateFunc: validate.EventSubscriptionAdvancedFilterKey,
 							},
 							"values": {
 								Type:     pluginsdk.TypeList,
@@ -515,14 +993,15 @@ func eventSubscriptionSchemaAdvancedFilter() *pluginsdk.Schema {
 							"key": {
 								Type:         pluginsdk.TypeString,
 								Required:     true,
-								ValidateFunc: validation.StringIsNotEmpty,
+								ValidateFunc: validate.EventSubscriptionAdvancedFilterKey,
 							},
 							"values": {
 								Type:     pluginsdk.TypeList,
 								Required: true,
 								MaxItems: 25,
 								Elem: &pluginsdk.Schema{
-									Type: pluginsdk.TypeString,
+									Type:         pluginsdk.TypeString,
+									ValidateFunc: validation.StringLenBetween(0, 512),
 								},
 							},
 						},
@@ -537,14 +1016,15 @@ func eventSubscriptionSchemaAdvancedFilter() *pluginsdk.Schema {
 							"key": {
 								Type:         pluginsdk.TypeString,
 								Required:     true,
-								ValidateFunc: validation.StringIsNotEmpty,
+								ValidateFunc: validate.EventSubscriptionAdvancedFilterKey,
 							},
 							"values": {
 								Type:     pluginsdk.TypeList,
 								Required: true,
 								MaxItems: 25,
 								Elem: &pluginsdk.Schema{
-									Type: pluginsdk.TypeString,
+									Type:         pluginsdk.TypeString,
+									ValidateFunc: validation.StringLenBetween(0, 512),
 								},
 							},
 						},
@@ -559,14 +1039,15 @@ func eventSubscriptionSchemaAdvancedFilter() *pluginsdk.Schema {
 							"key": {
 								Type:         pluginsdk.TypeString,
 								Required:     true,
-								ValidateFunc: validation.StringIsNotEmpty,
+								ValidateFunc: validate.EventSubscriptionAdvancedFilterKey,
 							},
 							"values": {
 								Type:     pluginsdk.TypeList,
 								Required: true,
 								MaxItems: 25,
 								Elem: &pluginsdk.Schema{
-									Type: pluginsdk.TypeString,
+									Type:         pluginsdk.TypeString,
+									ValidateFunc: validation.StringLenBetween(0, 512),
 								},
 							},
 						},
@@ -581,14 +1062,15 @@ func eventSubscriptionSchemaAdvancedFilter() *pluginsdk.Schema {
 							"key": {
 								Type:         pluginsdk.TypeString,
 								Required:     true,
-								ValidateFunc: validation.StringIsNotEmpty,
+								ValidateFunc: validate.EventSubscriptionAdvancedFilterKey,
 							},
 							"values": {
 								Type:     pluginsdk.TypeList,
 								Required: true,
 								MaxItems: 25,
 								Elem: &pluginsdk.Schema{
-									Type: pluginsdk.TypeString,
+									Type:         pluginsdk.TypeString,
+									ValidateFunc: validation.StringLenBetween(0, 512),
 								},
 							},
 						},
@@ -603,14 +1085,15 @@ func eventSubscriptionSchemaAdvancedFilter() *pluginsdk.Schema {
 							"key": {
 								Type:         pluginsdk.TypeString,
 								Required:     true,
-								ValidateFunc: validation.StringIsNotEmpty,
+								ValidateFunc: validate.EventSubscriptionAdvancedFilterKey,
 							},
 							"values": {
 								Type:     pluginsdk.TypeList,
 								Required: true,
 								MaxItems: 25,
 								Elem: &pluginsdk.Schema{
-									Type: pluginsdk.TypeString,
+									Type:         pluginsdk.TypeString,
+									ValidateFunc: validation.StringLenBetween(0, 512),
 								},
 							},
 						},
@@ -625,14 +1108,15 @@ func eventSubscriptionSchemaAdvancedFilter() *pluginsdk.Schema {
 							"key": {
 								Type:         pluginsdk.TypeString,
 								Required:     true,
-								ValidateFunc: validation.StringIsNotEmpty,
+								ValidateFunc: validate.EventSubscriptionAdvancedFilterKey,
 							},
 							"values": {
 								Type:     pluginsdk.TypeList,
 								Required: true,
 								MaxItems: 25,
 								Elem: &pluginsdk.Schema{
-									Type: pluginsdk.TypeString,
+									Type:         pluginsdk.TypeString,
+									ValidateFunc: validation.StringLenBetween(0, 512),
 								},
 							},
 						},
@@ -647,14 +1131,15 @@ func eventSubscriptionSchemaAdvancedFilter() *pluginsdk.Schema {
 							"key": {
 								Type:         pluginsdk.TypeString,
 								Required:     true,
-								ValidateFunc: validation.StringIsNotEmpty,
+								ValidateFunc: validate.EventSubscriptionAdvancedFilterKey,
 							},
 							"values": {
 								Type:     pluginsdk.TypeList,
 								Required: true,
 								MaxItems: 25,
 								Elem: &pluginsdk.Schema{
-									Type: pluginsdk.TypeString,
+									Type:         pluginsdk.TypeString,
+									ValidateFunc: validation.StringLenBetween(0, 512),
 								},
 							},
 						},
@@ -669,14 +1154,15 @@ func eventSubscriptionSchemaAdvancedFilter() *pluginsdk.Schema {
 							"key": {
 								Type:         pluginsdk.TypeString,
 								Required:     true,
-								ValidateFunc: validation.StringIsNotEmpty,
+								ValidateFunc: validate.EventSubscriptionAdvancedFilterKey,
 							},
 							"values": {
 								Type:     pluginsdk.TypeList,
 								Required: true,
 								MaxItems: 25,
 								Elem: &pluginsdk.Schema{
-									Type: pluginsdk.TypeString,
+									Type:         pluginsdk.TypeString,
+									ValidateFunc: validation.StringLenBetween(0, 512),
 								},
 							},
 						},
@@ -691,7 +1177,7 @@ func eventSubscriptionSchemaAdvancedFilter() *pluginsdk.Schema {
 							"key": {
 								Type:         pluginsdk.TypeString,
 								Required:     true,
-								ValidateFunc: validation.StringIsNotEmpty,
+								ValidateFunc: validate.EventSubscriptionAdvancedFilterKey,
 							},
 						},
 					},
@@ -705,7 +1191,7 @@ func eventSubscriptionSchemaAdvancedFilter() *pluginsdk.Schema {
 							"key": {
 								Type:         pluginsdk.TypeString,
 								Required:     true,
-								ValidateFunc: validation.StringIsNotEmpty,
+								ValidateFunc: validate.EventSubscriptionAdvancedFilterKey,
 							},
 						},
 					},
@@ -719,7 +1205,7 @@ func eventSubscriptionSchemaAdvancedFilter() *pluginsdk.Schema {
 							"key": {
 								Type:         pluginsdk.TypeString,
 								Required:     true,
-								ValidateFunc: validation.StringIsNotEmpty,
+								ValidateFunc: validate.EventSubscriptionAdvancedFilterKey,
 							},
 							"values": {
 								Type:     pluginsdk.TypeList,
@@ -746,7 +1232,7 @@ func eventSubscriptionSchemaAdvancedFilter() *pluginsdk.Schema {
 							"key": {
 								Type:         pluginsdk.TypeString,
 								Required:     true,
-								ValidateFunc: validation.StringIsNotEmpty,
+								ValidateFunc: validate.EventSubscriptionAdvancedFilterKey,
 							},
 							"values": {
 								Type:     pluginsdk.TypeList,
@@ -780,18 +1266,25 @@ func eventSubscriptionSchemaStorageBlobDeadletterDestination() *pluginsdk.Schema
 				"storage_account_id": {
 					Type:         pluginsdk.TypeString,
 					Required:     true,
-					ValidateFunc: azure.ValidateResourceID,
+					ValidateFunc: storageValidate.StorageAccountID,
 				},
 				"storage_blob_container_name": {
 					Type:         pluginsdk.TypeString,
 					Required:     true,
-					ValidateFunc: validation.StringIsNotEmpty,
+					ValidateFunc: storageValidate.StorageContainerName,
 				},
 			},
 		},
 	}
 }
 
+const (
+	// eventSubscriptionDefaultMaxDeliveryAttempts is the number of delivery attempts used by the service when none is specified
+	eventSubscriptionDefaultMaxDeliveryAttempts = 30
+	// eventSubscriptionDefaultEventTimeToLive is the time to live (in minutes) used by the service when none is specified
+	eventSubscriptionDefaultEventTimeToLive = 1440
+)
+
 func eventSubscriptionSchemaRetryPolicy() *pluginsdk.Schema {
 	return &pluginsdk.Schema{
 		Type:     pluginsdk.TypeList,
@@ -800,15 +1293,19 @@ func eventSubscriptionSchemaRetryPolicy() *pluginsdk.Schema {
 		Computed: true,
 		Elem: &pluginsdk.Resource{
 			Schema: map[string]*pluginsdk.Schema{
+				// when only one of these is specified the other falls back to the service default
 				"max_delivery_attempts": {
 					Type:         pluginsdk.TypeInt,
-					Required:     true,
+					Optional:     true,
+					Default:      eventSubscriptionDefaultMaxDeliveryAttempts,
 					ValidateFunc: validation.IntBetween(1, 30),
 				},
+				// the default is also the maximum time to live supported by the service
 				"event_time_to_live": {
 					Type:         pluginsdk.TypeInt,
-					Required:     true,
-					ValidateFunc: validation.IntBetween(1, 1440),
+					Optional:     true,
+					Default:      eventSubscriptionDefaultEventTimeToLive,
+					ValidateFunc: validation.IntBetween(1, eventSubscriptionDefaultEventTimeToLive),
 				},
 			},
 		},
@@ -820,7 +1317,8 @@ func eventSubscriptionSchemaLabels() *pluginsdk.Schema {
 		Type:     pluginsdk.TypeList,
 		Optional: true,
 		Elem: &pluginsdk.Schema{
-			Type: pluginsdk.TypeString,
+			Type:         pluginsdk.TypeString,
+			ValidateFunc: validate.EventSubscriptionLabel,
 		},
 	}
 }
@@ -841,8 +1339,9 @@ func eventSubscriptionSchemaIdentity() *schema.Schema {
 					}, false),
 				},
 				"user_assigned_identity": {
-					Type:     schema.TypeString,
-					Optional: true,
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: msivalidate.UserAssignedIdentityID,
 				},
 			},
 		},
@@ -863,6 +1362,16 @@ func expandEventGridExpirationTime(d *schema.ResourceData) (*date.Time, error) {
 		return &date.Time{Time: parsedExpirationTimeUtc}, nil
 	}
 
+	// this is normally resolved into `expiration_time_utc` during the plan, unless it wasn't known at the time
+	if relative, ok := d.GetOk("expiration_relative_to_now"); ok {
+		duration, err := time.ParseDuration(relative.(string))
+		if err != nil {
+			return nil, fmt.Errorf("parsing `expiration_relative_to_now`: %+v", err)
+		}
+
+		return &date.Time{Time: time.Now().UTC().Add(duration)}, nil
+	}
+
 	return nil, nil
 }
 
@@ -1123,6 +1632,8 @@ func expandEventGridEventSubscriptionWebhookEndpoint(d *pluginsdk.ResourceData)
 	return webhookDestination
 }
 
+// expandEventGridEventSubscriptionFilter expands the filter fields which are shared by each type of Event Subscription,
+// and so doesn't depend on the scope that the Event Subscription is within
 func expandEventGridEventSubscriptionFilter(d *pluginsdk.ResourceData) (*eventgrid.EventSubscriptionFilter, error) {
 	filter := &eventgrid.EventSubscriptionFilter{}
 
@@ -1145,8 +1656,20 @@ func expandEventGridEventSubscriptionFilter(d *pluginsdk.ResourceData) (*eventgr
 
 	if advancedFilter, ok := d.GetOk("advanced_filter"); ok {
 		advancedFilters := make([]eventgrid.BasicAdvancedFilter, 0)
-		for filterKey, filterSchema := range advancedFilter.([]interface{})[0].(map[string]interface{}) {
-			for _, options := range filterSchema.([]interface{}) {
+		config := advancedFilter.([]interface{})[0].(map[string]interface{})
+		if err := validateEventGridEventSubscriptionAdvancedFilters(config); err != nil {
+			return nil, err
+		}
+
+		// iterate the operators in a stable order so the filters are always sent to the API in the same order
+		operatorTypes := make([]string, 0, len(config))
+		for operatorType := range config {
+			operatorTypes = append(operatorTypes, operatorType)
+		}
+		sort.Strings(operatorTypes)
+
+		for _, filterKey := range operatorTypes {
+			for _, options := range config[filterKey].([]interface{}) {
 				if filter, err := expandAdvancedFilter(filterKey, options.(map[string]interface{})); err == nil {
 					advancedFilters = append(advancedFilters, filter)
 				} else {
@@ -1157,8 +1680,11 @@ func expandEventGridEventSubscriptionFilter(d *pluginsdk.ResourceData) (*eventgr
 		filter.AdvancedFilters = &advancedFilters
 	}
 
-	if v, ok := d.GetOk("advanced_filtering_on_arrays_enabled"); ok {
-		filter.EnableAdvancedFilteringOnArrays = utils.Bool(v.(bool))
+	// only send the flag when there are advanced filters for it to apply to (or it's been explicitly enabled),
+	// so that an omitted value isn't sent as `false` on subscriptions without advanced filters - unless it's being
+	// disabled, in which case it's always sent so that the service's default for the API version doesn't apply
+	if enabled := d.Get("advanced_filtering_on_arrays_enabled").(bool); enabled || filter.AdvancedFilters != nil || d.HasChange("advanced_filtering_on_arrays_enabled") {
+		filter.EnableAdvancedFilteringOnArrays = utils.Bool(enabled)
 	}
 
 	return filter, nil
@@ -1259,6 +1785,26 @@ func expandEventGridEventSubscriptionRetryPolicy(d *pluginsdk.ResourceData) *eve
 	return nil
 }
 
+// expandEventGridEventSubscriptionDeliveryDestination returns the destination either at the top level or, when a
+// `delivery_identity` is configured, nested within DeliveryWithResourceIdentity - since the whole Event Subscription
+// is PUT on update, only ever populating one of these ensures adding, changing or removing the identity takes effect
+func expandEventGridEventSubscriptionDeliveryDestination(d *pluginsdk.ResourceData, destination eventgrid.BasicEventSubscriptionDestination) (eventgrid.BasicEventSubscriptionDestination, *eventgrid.DeliveryWithResourceIdentity, error) {
+	v, ok := d.GetOk("delivery_identity")
+	if !ok {
+		return destination, nil, nil
+	}
+
+	deliveryIdentity, err := expandEventGridEventSubscriptionIdentity(v.([]interface{}))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nil, &eventgrid.DeliveryWithResourceIdentity{
+		Identity:    deliveryIdentity,
+		Destination: destination,
+	}, nil
+}
+
 func expandEventGridEventSubscriptionIdentity(input []interface{}) (*eventgrid.EventSubscriptionIdentity, error) {
 	if len(input) == 0 || input[0] == nil {
 		return &eventgrid.EventSubscriptionIdentity{
@@ -1272,14 +1818,32 @@ func expandEventGridEventSubscriptionIdentity(input []interface{}) (*eventgrid.E
 		Type: identityType,
 	}
 
+	if err := validateEventGridEventSubscriptionIdentity(identity); err != nil {
+		return nil, err
+	}
+
+	if identityType == eventgrid.UserAssigned {
+		eventgridIdentity.UserAssignedIdentity = utils.String(identity["user_assigned_identity"].(string))
+	}
+
+	return &eventgridIdentity, nil
+}
+
+// validateEventGridEventSubscriptionIdentity checks that `user_assigned_identity` is only (and always) specified for
+// a `UserAssigned` identity - this doesn't make any API calls so that it can also be checked during the plan
+func validateEventGridEventSubscriptionIdentity(identity map[string]interface{}) error {
+	identityType := eventgrid.EventSubscriptionIdentityType(identity["type"].(string))
 	userAssignedIdentity := identity["user_assigned_identity"].(string)
+
 	if identityType == eventgrid.UserAssigned {
-		eventgridIdentity.UserAssignedIdentity = utils.String(userAssignedIdentity)
+		if userAssignedIdentity == "" {
+			return ErrEventSubscriptionUserAssignedIdentityNotSpecified
+		}
 	} else if len(userAssignedIdentity) > 0 {
-		return nil, fmt.Errorf("`user_assigned_identity` can only be specified when `type` is `UserAssigned`; but `type` is currently %q", identityType)
+		return fmt.Errorf("`user_assigned_identity` can only be specified when `type` is `UserAssigned`; but `type` is currently %q", identityType)
 	}
 
-	return &eventgridIdentity, nil
+	return nil
 }
 
 func flattenEventGridEventSubscriptionEventhubEndpoint(input *eventgrid.EventHubEventSubscriptionDestination) []interface{} {
@@ -1314,13 +1878,14 @@ func flattenDeliveryProperties(d *pluginsdk.ResourceData, input *[]eventgrid.Bas
 				attributeMapping["secret"] = staticMapping.IsSecret
 			}
 
-			if *staticMapping.IsSecret {
+			isSecret := staticMapping.IsSecret != nil && *staticMapping.IsSecret
+			if isSecret || (staticMapping.Name != nil && isAuthorizationDeliveryProperty(*staticMapping.Name)) {
 				// If this is a secret, the Azure API just returns a value of 'Hidden',
 				// so we need to lookup the value that was provided from config to return
 				propertiesFromConfig := expandDeliveryProperties(d)
 				for _, v := range propertiesFromConfig {
 					if configMap, ok := v.AsStaticDeliveryAttributeMapping(); ok {
-						if *configMap.Name == *staticMapping.Name {
+						if staticMapping.Name != nil && strings.EqualFold(*configMap.Name, *staticMapping.Name) {
 							if configMap.Value != nil {
 								attributeMapping["value"] = configMap.Value
 							}
@@ -1371,7 +1936,7 @@ func flattenEventGridEventSubscriptionStorageQueueEndpoint(input *eventgrid.Stor
 	result := make(map[string]interface{})
 
 	if input.ResourceID != nil {
-		result["storage_account_id"] = *input.ResourceID
+		result["storage_account_id"] = normalizeStorageAccountID(*input.ResourceID)
 	}
 	if input.QueueName != nil {
 		result["queue_name"] = *input.QueueName
@@ -1384,6 +1949,21 @@ func flattenEventGridEventSubscriptionStorageQueueEndpoint(input *eventgrid.Stor
 	return []interface{}{result}
 }
 
+// normalizeStorageAccountID returns the canonical Storage Account ID - when the Storage Account is
+// only reachable via a Private Endpoint the API can return the Account Name with its DNS suffix appended
+func normalizeStorageAccountID(input string) string {
+	id, err := storageParse.StorageAccountID(input)
+	if err != nil {
+		return input
+	}
+
+	if i := strings.Index(id.Name, "."); i > 0 {
+		id.Name = id.Name[:i]
+	}
+
+	return id.ID()
+}
+
 func flattenEventGridEventSubscriptionAzureFunctionEndpoint(input *eventgrid.AzureFunctionEventSubscriptionDestination) []interface{} {
 	results := make([]interface{}, 0)
 
@@ -1413,23 +1993,75 @@ func flattenEventGridEventSubscriptionAzureFunctionEndpoint(input *eventgrid.Azu
 	})
 }
 
-func flattenEventGridEventSubscriptionWebhookEndpoint(input *eventgrid.WebHookEventSubscriptionDestination, fullURL *eventgrid.EventSubscriptionFullURL) []interface{} {
+// eventSubscriptionWebhookFullURL retrieves the full url (including any secrets in the query string) of a WebHook
+// endpoint. This is only available for WebHook destinations - Azure Function destinations are referenced by the
+// Function's resource ID and EventGrid manages the key used to invoke it, so there's no url to retrieve and
+// `getFullURL` isn't called for them (or any other destination). Since this requires permission to list secrets,
+// when this is missing the base url is used instead of failing the read
+func eventSubscriptionWebhookFullURL(destination eventgrid.BasicEventSubscriptionDestination, getFullURL func() (eventgrid.EventSubscriptionFullURL, error)) (*eventgrid.EventSubscriptionFullURL, error) {
+	if destination == nil {
+		return nil, nil
+	}
+	if _, ok := destination.AsWebHookEventSubscriptionDestination(); !ok {
+		return nil, nil
+	}
+
+	fullURL, err := getFullURL()
+	if err != nil {
+		if utils.ResponseWasForbidden(fullURL.Response) {
+			log.Printf("[WARN] Unable to retrieve the full url of the WebHook endpoint, falling back to the base url: %+v", err)
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &fullURL, nil
+}
+
+// eventSubscriptionWebhookURLWithoutSecrets strips the query string (e.g. an Azure Function key or a signature),
+// fragment and any credentials from the full url of a WebHook, so that it can be safely displayed
+func eventSubscriptionWebhookURLWithoutSecrets(input string) string {
+	u, err := url.Parse(input)
+	if err != nil {
+		// the full url can't be displayed safely if it can't be parsed
+		return ""
+	}
+
+	u.User = nil
+	u.RawQuery = ""
+	u.ForceQuery = false
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
+
+// flattenEventGridEventSubscriptionWebhookEndpoint flattens the WebHook endpoint, where existingURL is the `url` from
+// the state/configuration which is kept when the full url couldn't be retrieved and it's for the same endpoint
+func flattenEventGridEventSubscriptionWebhookEndpoint(input *eventgrid.WebHookEventSubscriptionDestination, fullURL *eventgrid.EventSubscriptionFullURL, existingURL string) []interface{} {
 	results := make([]interface{}, 0)
 
 	if input == nil {
 		return results
 	}
 
-	webhookURL := ""
-	if fullURL != nil {
-		webhookURL = *fullURL.EndpointURL
-	}
-
 	webhookBaseURL := ""
 	if input.EndpointBaseURL != nil {
 		webhookBaseURL = *input.EndpointBaseURL
 	}
 
+	webhookURL := webhookBaseURL
+	if fullURL != nil && fullURL.EndpointURL != nil {
+		webhookURL = *fullURL.EndpointURL
+
+		if webhookBaseURL == "" {
+			webhookBaseURL = eventSubscriptionWebhookURLWithoutSecrets(webhookURL)
+		}
+	} else if existingURL != "" && webhookBaseURL != "" && eventSubscriptionWebhookURLWithoutSecrets(existingURL) == webhookBaseURL {
+		// the base url doesn't contain the query string of the configured url, which would otherwise show a diff
+		webhookURL = existingURL
+	}
+
 	maxEventsPerBatch := 0
 	if input.MaxEventsPerBatch != nil {
 		maxEventsPerBatch = int(*input.MaxEventsPerBatch)
@@ -1460,9 +2092,18 @@ func flattenEventGridEventSubscriptionWebhookEndpoint(input *eventgrid.WebHookEv
 	})
 }
 
-func flattenEventGridEventSubscriptionSubjectFilter(filter *eventgrid.EventSubscriptionFilter) []interface{} {
-	if (filter.SubjectBeginsWith != nil && *filter.SubjectBeginsWith == "") && (filter.SubjectEndsWith != nil && *filter.SubjectEndsWith == "") {
-		return nil
+func flattenEventGridEventSubscriptionSubjectFilter(d *pluginsdk.ResourceData, filter *eventgrid.EventSubscriptionFilter) []interface{} {
+	// the service returns an empty subject filter when only advanced filters are configured, which is indistinguishable
+	// from a `subject_filter` with only `case_sensitive` set to `false` - so this is only omitted when it isn't configured
+	if filter == nil || (isEmptyString(filter.SubjectBeginsWith) && isEmptyString(filter.SubjectEndsWith) && (filter.IsSubjectCaseSensitive == nil || !*filter.IsSubjectCaseSensitive)) {
+		if v := d.Get("subject_filter").([]interface{}); len(v) == 0 || v[0] == nil {
+			return []interface{}{}
+		}
+		return []interface{}{
+			map[string]interface{}{
+				"case_sensitive": false,
+			},
+		}
 	}
 	result := make(map[string]interface{})
 
@@ -1481,10 +2122,19 @@ func flattenEventGridEventSubscriptionSubjectFilter(filter *eventgrid.EventSubsc
 	return []interface{}{result}
 }
 
-func flattenEventGridEventSubscriptionAdvancedFilter(input *eventgrid.EventSubscriptionFilter) []interface{} {
+func flattenEventGridEventSubscriptionEnableAdvancedFilteringOnArrays(filter *eventgrid.EventSubscriptionFilter) bool {
+	// the service omits this when it's disabled
+	return filter != nil && filter.EnableAdvancedFilteringOnArrays != nil && *filter.EnableAdvancedFilteringOnArrays
+}
+
+func isEmptyString(input *string) bool {
+	return input == nil || *input == ""
+}
+
+func flattenEventGridEventSubscriptionAdvancedFilter(d *pluginsdk.ResourceData, input *eventgrid.EventSubscriptionFilter) ([]interface{}, error) {
 	results := make([]interface{}, 0)
 	if input == nil || input.AdvancedFilters == nil {
-		return results
+		return results, nil
 	}
 
 	boolEquals := make([]interface{}, 0)
@@ -1564,32 +2214,99 @@ func flattenEventGridEventSubscriptionAdvancedFilter(input *eventgrid.EventSubsc
 			isNotNull = append(isNotNull, flattenKey(f.Key))
 		case eventgrid.IsNullOrUndefinedAdvancedFilter:
 			isNullOrUndefined = append(isNullOrUndefined, flattenKey(f.Key))
+		default:
+			// operators introduced by the service after this API version are returned as the base type
+			operatorType := fmt.Sprintf("%T", item)
+			key := ""
+			if v, ok := item.AsAdvancedFilter(); ok && v != nil {
+				operatorType = string(v.OperatorType)
+				if v.Key != nil {
+					key = *v.Key
+				}
+			}
+			if features.EventGridStrictAdvancedFilters() {
+				return nil, fmt.Errorf("the advanced filter operator %q (Key %q) isn't supported by the provider", operatorType, key)
+			}
+			log.Printf("[WARN] Ignoring the advanced filter operator %q (Key %q) since it isn't supported by the provider - this will show as a diff", operatorType, key)
 		}
 	}
 
 	return []interface{}{
 		map[string][]interface{}{
-			"bool_equals":                   boolEquals,
-			"number_greater_than":           numberGreaterThan,
-			"number_greater_than_or_equals": numberGreaterThanOrEquals,
-			"number_less_than":              numberLessThan,
-			"number_less_than_or_equals":    numberLessThanOrEquals,
-			"number_in":                     numberIn,
-			"number_not_in":                 numberNotIn,
-			"number_in_range":               numberInRange,
-			"number_not_in_range":           numberNotInRange,
-			"string_begins_with":            stringBeginsWith,
-			"string_not_begins_with":        stringNotBeginsWith,
-			"string_ends_with":              stringEndsWith,
-			"string_not_ends_with":          stringNotEndsWith,
-			"string_contains":               stringContains,
-			"string_not_contains":           stringNotContains,
-			"string_in":                     stringIn,
-			"string_not_in":                 stringNotIn,
-			"is_not_null":                   isNotNull,
-			"is_null_or_undefined":          isNullOrUndefined,
+			"bool_equals":                   orderAdvancedFiltersByConfig(d, "bool_equals", boolEquals),
+			"number_greater_than":           orderAdvancedFiltersByConfig(d, "number_greater_than", numberGreaterThan),
+			"number_greater_than_or_equals": orderAdvancedFiltersByConfig(d, "number_greater_than_or_equals", numberGreaterThanOrEquals),
+			"number_less_than":              orderAdvancedFiltersByConfig(d, "number_less_than", numberLessThan),
+			"number_less_than_or_equals":    orderAdvancedFiltersByConfig(d, "number_less_than_or_equals", numberLessThanOrEquals),
+			"number_in":                     orderAdvancedFiltersByConfig(d, "number_in", numberIn),
+			"number_not_in":                 orderAdvancedFiltersByConfig(d, "number_not_in", numberNotIn),
+			"number_in_range":               orderAdvancedFiltersByConfig(d, "number_in_range", numberInRange),
+			"number_not_in_range":           orderAdvancedFiltersByConfig(d, "number_not_in_range", numberNotInRange),
+			"string_begins_with":            orderAdvancedFiltersByConfig(d, "string_begins_with", stringBeginsWith),
+			"string_not_begins_with":        orderAdvancedFiltersByConfig(d, "string_not_begins_with", stringNotBeginsWith),
+			"string_ends_with":              orderAdvancedFiltersByConfig(d, "string_ends_with", stringEndsWith),
+			"string_not_ends_with":          orderAdvancedFiltersByConfig(d, "string_not_ends_with", stringNotEndsWith),
+			"string_contains":               orderAdvancedFiltersByConfig(d, "string_contains", stringContains),
+			"string_not_contains":           orderAdvancedFiltersByConfig(d, "string_not_contains", stringNotContains),
+			"string_in":                     orderAdvancedFiltersByConfig(d, "string_in", stringIn),
+			"string_not_in":                 orderAdvancedFiltersByConfig(d, "string_not_in", stringNotIn),
+			"is_not_null":                   orderAdvancedFiltersByConfig(d, "is_not_null", isNotNull),
+			"is_null_or_undefined":          orderAdvancedFiltersByConfig(d, "is_null_or_undefined", isNullOrUndefined),
 		},
+	}, nil
+}
+
+// orderAdvancedFiltersByConfig returns the filters for an operator in the order they're defined in the config,
+// since the API doesn't guarantee the order they're returned in - any filters which aren't in the config (e.g.
+// when importing) are appended in the order returned by the API
+func orderAdvancedFiltersByConfig(d *pluginsdk.ResourceData, operatorType string, input []interface{}) []interface{} {
+	configured := d.Get(fmt.Sprintf("advanced_filter.0.%s", operatorType)).([]interface{})
+	if len(configured) == 0 || len(input) < 2 {
+		return input
+	}
+
+	remaining := make([]interface{}, len(input))
+	copy(remaining, input)
+
+	results := make([]interface{}, 0, len(input))
+	for _, config := range configured {
+		if config == nil {
+			continue
+		}
+		for i, item := range remaining {
+			if item != nil && advancedFilterMatchesConfig(item.(map[string]interface{}), config.(map[string]interface{})) {
+				results = append(results, item)
+				remaining[i] = nil
+				break
+			}
+		}
+	}
+
+	for _, item := range remaining {
+		if item != nil {
+			results = append(results, item)
+		}
 	}
+
+	return results
+}
+
+// advancedFilterMatchesConfig compares the key and any values of a filter
+func advancedFilterMatchesConfig(filter map[string]interface{}, config map[string]interface{}) bool {
+	if filter["key"].(string) != config["key"].(string) {
+		return false
+	}
+
+	values, ok := filter["values"]
+	if !ok {
+		return true
+	}
+	configValues, ok := config["values"]
+	if !ok {
+		return true
+	}
+
+	return fmt.Sprintf("%v", values) == fmt.Sprintf("%v", configValues)
 }
 
 func flattenEventGridEventSubscriptionStorageBlobDeadLetterDestination(dest *eventgrid.StorageBlobDeadLetterDestination) []interface{} {
@@ -1609,18 +2326,60 @@ func flattenEventGridEventSubscriptionStorageBlobDeadLetterDestination(dest *eve
 	return []interface{}{result}
 }
 
-func flattenEventGridEventSubscriptionRetryPolicy(retryPolicy *eventgrid.RetryPolicy) []interface{} {
-	result := make(map[string]interface{})
+// flattenEventGridEventSubscriptionLabels orders the labels returned by the API to match the configuration, since
+// the API doesn't guarantee the order they're returned in; any labels not in the configuration are kept at the end
+func flattenEventGridEventSubscriptionLabels(d *pluginsdk.ResourceData, input *[]string) []interface{} {
+	labels := utils.FlattenStringSlice(input)
+	configured := d.Get("labels").([]interface{})
+	if len(configured) == 0 || len(labels) < 2 {
+		return labels
+	}
+
+	remaining := make([]interface{}, len(labels))
+	copy(remaining, labels)
+
+	results := make([]interface{}, 0, len(labels))
+	for _, config := range configured {
+		for i, label := range remaining {
+			if label != nil && label == config {
+				results = append(results, label)
+				remaining[i] = nil
+				break
+			}
+		}
+	}
 
-	if v := retryPolicy.EventTimeToLiveInMinutes; v != nil {
-		result["event_time_to_live"] = int(*v)
+	for _, label := range remaining {
+		if label != nil {
+			results = append(results, label)
+		}
 	}
 
-	if v := retryPolicy.MaxDeliveryAttempts; v != nil {
-		result["max_delivery_attempts"] = int(*v)
+	return results
+}
+
+// flattenEventGridEventSubscriptionRetryPolicy always returns both fields, falling back to the service defaults
+// for any which are omitted by the API so that the shape of `retry_policy` doesn't vary between reads
+func flattenEventGridEventSubscriptionRetryPolicy(retryPolicy *eventgrid.RetryPolicy) []interface{} {
+	eventTimeToLive := eventSubscriptionDefaultEventTimeToLive
+	maxDeliveryAttempts := eventSubscriptionDefaultMaxDeliveryAttempts
+
+	if retryPolicy != nil {
+		if v := retryPolicy.EventTimeToLiveInMinutes; v != nil {
+			eventTimeToLive = int(*v)
+		}
+
+		if v := retryPolicy.MaxDeliveryAttempts; v != nil {
+			maxDeliveryAttempts = int(*v)
+		}
 	}
 
-	return []interface{}{result}
+	return []interface{}{
+		map[string]interface{}{
+			"event_time_to_live":    eventTimeToLive,
+			"max_delivery_attempts": maxDeliveryAttempts,
+		},
+	}
 }
 
 func flattenValue(inputKey *string, inputValue *interface{}) map[string]interface{} {
@@ -1684,6 +2443,95 @@ func flattenKey(inputKey *string) map[string]interface{} {
 	}
 }
 
+// flattenEventGridEventSubscriptionDeliveryDestination returns the destination of the Event Subscription along with the
+// flattened `delivery_identity` - the destination is nested within DeliveryWithResourceIdentity when an identity is used,
+// however the top-level Destination is used as a fallback since either may be returned when the identity is changed outside of Terraform
+func flattenEventGridEventSubscriptionDeliveryDestination(props *eventgrid.EventSubscriptionProperties) (eventgrid.BasicEventSubscriptionDestination, []interface{}) {
+	destination := props.Destination
+	identity := make([]interface{}, 0)
+	if deliveryIdentity := props.DeliveryWithResourceIdentity; deliveryIdentity != nil {
+		if deliveryIdentity.Destination != nil {
+			destination = deliveryIdentity.Destination
+		}
+		identity = flattenEventGridEventSubscriptionIdentity(deliveryIdentity.Identity)
+	}
+
+	if destination == nil {
+		// an empty destination matches none of the endpoint types, so that each of them is cleared
+		destination = eventgrid.EventSubscriptionDestination{}
+	}
+
+	return destination, identity
+}
+
+// eventSubscriptionEndpointTypesForDestination returns the endpoint types which are populated from the destination
+func eventSubscriptionEndpointTypesForDestination(destination eventgrid.BasicEventSubscriptionDestination) []string {
+	if _, ok := destination.AsAzureFunctionEventSubscriptionDestination(); ok {
+		return []string{string(AzureFunctionEndpoint)}
+	}
+	if _, ok := destination.AsEventHubEventSubscriptionDestination(); ok {
+		return []string{string(EventHubEndpoint), string(EventHubEndpointID)}
+	}
+	if _, ok := destination.AsHybridConnectionEventSubscriptionDestination(); ok {
+		return []string{string(HybridConnectionEndpoint), string(HybridConnectionEndpointID)}
+	}
+	if _, ok := destination.AsServiceBusQueueEventSubscriptionDestination(); ok {
+		return []string{string(ServiceBusQueueEndpointID)}
+	}
+	if _, ok := destination.AsServiceBusTopicEventSubscriptionDestination(); ok {
+		return []string{string(ServiceBusTopicEndpointID)}
+	}
+	if _, ok := destination.AsStorageQueueEventSubscriptionDestination(); ok {
+		return []string{string(StorageQueueEndpoint)}
+	}
+	if _, ok := destination.AsWebHookEventSubscriptionDestination(); ok {
+		return []string{string(WebHookEndpoint)}
+	}
+	return []string{}
+}
+
+// flattenEventGridEventSubscriptionEndpointType returns the type of endpoint (e.g. `WebHook`) which the destination delivers to,
+// or an empty string when the destination isn't one of the endpoint types supported by the provider
+func flattenEventGridEventSubscriptionEndpointType(destination eventgrid.BasicEventSubscriptionDestination) string {
+	if _, ok := destination.AsAzureFunctionEventSubscriptionDestination(); ok {
+		return string(eventgrid.EndpointTypeAzureFunction)
+	}
+	if _, ok := destination.AsEventHubEventSubscriptionDestination(); ok {
+		return string(eventgrid.EndpointTypeEventHub)
+	}
+	if _, ok := destination.AsHybridConnectionEventSubscriptionDestination(); ok {
+		return string(eventgrid.EndpointTypeHybridConnection)
+	}
+	if _, ok := destination.AsServiceBusQueueEventSubscriptionDestination(); ok {
+		return string(eventgrid.EndpointTypeServiceBusQueue)
+	}
+	if _, ok := destination.AsServiceBusTopicEventSubscriptionDestination(); ok {
+		return string(eventgrid.EndpointTypeServiceBusTopic)
+	}
+	if _, ok := destination.AsStorageQueueEventSubscriptionDestination(); ok {
+		return string(eventgrid.EndpointTypeStorageQueue)
+	}
+	if _, ok := destination.AsWebHookEventSubscriptionDestination(); ok {
+		return string(eventgrid.EndpointTypeWebHook)
+	}
+	return ""
+}
+
+// resetEventGridEventSubscriptionEndpoints clears the endpoint types which aren't populated from the destination, so that
+// the endpoint being switched outside of Terraform (e.g. whilst migrating to identity-based delivery) shows up as a diff
+func resetEventGridEventSubscriptionEndpoints(d *pluginsdk.ResourceData, destination eventgrid.BasicEventSubscriptionDestination, endpointTypes []string) error {
+	populated := eventSubscriptionEndpointTypesForDestination(destination)
+	for _, endpointType := range endpointTypes {
+		if utils.SliceContainsValue(populated, endpointType) {
+			continue
+		}
+		if err := d.Set(endpointType, nil); err != nil {
+			return fmt.Errorf("setting `%s`: %+v", endpointType, err)
+		}
+	}
+	return nil
+}
+
 func flattenEventGridEventSubscriptionIdentity(input *eventgrid.EventSubscriptionIdentity) []interface{} {
 	if input == nil || string(input.Type) == "None" {
 		return []interface{}{}
@@ -2,9 +2,12 @@ package eventgrid
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/preview/eventgrid/mgmt/2020-10-15-preview/eventgrid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/eventgrid/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
@@ -106,8 +109,9 @@ func eventSubscriptionInboundIPRule() *pluginsdk.Schema {
 		Elem: &pluginsdk.Resource{
 			Schema: map[string]*pluginsdk.Schema{
 				"ip_mask": {
-					Type:     pluginsdk.TypeString,
-					Required: true,
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validate.InboundIPRuleIPMask,
 				},
 				"action": {
 					Type:     pluginsdk.TypeString,
@@ -176,6 +180,41 @@ func flattenInboundIPRules(in *[]eventgrid.InboundIPRule) []interface{} {
 	return rules
 }
 
+// orderInboundIPRulesByConfig orders the `inbound_ip_rule` blocks returned by the API to match the configuration,
+// since the API doesn't guarantee the order they're returned in; any rules not in the configuration are kept at the end
+func orderInboundIPRulesByConfig(d *pluginsdk.ResourceData, input []interface{}) []interface{} {
+	configured := d.Get("inbound_ip_rule").([]interface{})
+	if len(configured) == 0 || len(input) < 2 {
+		return input
+	}
+
+	remaining := make([]interface{}, len(input))
+	copy(remaining, input)
+
+	results := make([]interface{}, 0, len(input))
+	for _, config := range configured {
+		if config == nil {
+			continue
+		}
+		ipMask := config.(map[string]interface{})["ip_mask"].(string)
+		for i, item := range remaining {
+			if item != nil && item.(map[string]interface{})["ip_mask"] == ipMask {
+				results = append(results, item)
+				remaining[i] = nil
+				break
+			}
+		}
+	}
+
+	for _, item := range remaining {
+		if item != nil {
+			results = append(results, item)
+		}
+	}
+
+	return results
+}
+
 func expandIdentity(input []interface{}) (*eventgrid.IdentityInfo, error) {
 	if len(input) == 0 || input[0] == nil {
 		return &eventgrid.IdentityInfo{
@@ -237,3 +276,33 @@ func flattenIdentity(input *eventgrid.IdentityInfo) []interface{} {
 		},
 	}
 }
+
+// eventGridResourceTypeFromID returns the type of resource (e.g. `Microsoft.Storage/storageAccounts`)
+// which the specified Resource ID refers to, or an empty string when this can't be determined
+func eventGridResourceTypeFromID(input string) string {
+	id, err := azure.ParseAzureResourceID(input)
+	if err != nil {
+		return ""
+	}
+
+	segments := strings.Split(strings.Trim(input, "/"), "/")
+	if segments[0] != "subscriptions" {
+		return ""
+	}
+
+	switch {
+	case id.ResourceGroup == "" && id.Provider == "" && len(id.Path) == 0:
+		return "Microsoft.Resources/subscriptions"
+	case id.ResourceGroup != "" && id.Provider == "" && len(id.Path) == 0:
+		return "Microsoft.Resources/resourceGroups"
+	case id.ResourceGroup != "" && id.SecondaryProvider == "" && len(segments) >= 8 && segments[4] == "providers":
+		// the Path is unordered, so the types of any nested resources are taken from the ID in order
+		resourceType := id.Provider
+		for i := 6; i < len(segments); i += 2 {
+			resourceType += "/" + segments[i]
+		}
+		return resourceType
+	}
+
+	return ""
+}
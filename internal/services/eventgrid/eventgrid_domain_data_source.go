@@ -103,6 +103,11 @@ func dataSourceEventGridDomain() *pluginsdk.Resource {
 				Computed: true,
 			},
 
+			"metric_arm_resource_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"primary_access_key": {
 				Type:      pluginsdk.TypeString,
 				Computed:  true,
@@ -146,6 +151,7 @@ func dataSourceEventGridDomainRead(d *pluginsdk.ResourceData, meta interface{})
 
 	if props := resp.DomainProperties; props != nil {
 		d.Set("endpoint", props.Endpoint)
+		d.Set("metric_arm_resource_id", props.MetricResourceID)
 
 		d.Set("input_schema", string(props.InputSchema))
 
@@ -20,6 +20,7 @@ func TestAccEventGridDomainDataSource_basic(t *testing.T) {
 			Config: r.complete(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).Key("endpoint").Exists(),
+				check.That(data.ResourceName).Key("metric_arm_resource_id").Exists(),
 				check.That(data.ResourceName).Key("primary_access_key").Exists(),
 				check.That(data.ResourceName).Key("secondary_access_key").Exists(),
 				check.That(data.ResourceName).Key("input_mapping_fields.0.topic").Exists(),
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/eventgrid/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
@@ -147,6 +148,11 @@ func resourceEventGridDomain() *pluginsdk.Resource {
 				Computed: true,
 			},
 
+			"metric_arm_resource_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"primary_access_key": {
 				Type:      pluginsdk.TypeString,
 				Computed:  true,
@@ -263,23 +269,24 @@ func resourceEventGridDomainRead(d *pluginsdk.ResourceData, meta interface{}) er
 
 	if props := resp.DomainProperties; props != nil {
 		d.Set("endpoint", props.Endpoint)
+		d.Set("metric_arm_resource_id", props.MetricResourceID)
 
 		d.Set("input_schema", string(props.InputSchema))
 
 		inputMappingFields, err := flattenAzureRmEventgridDomainInputMapping(props.InputSchemaMapping)
 		if err != nil {
-			return fmt.Errorf("flattening `input_schema_mapping_fields` for EventGrid Domain %q (Resource Group %q): %s", id.Name, id.ResourceGroup, err)
+			return fmt.Errorf("flattening `input_mapping_fields` for EventGrid Domain %q (Resource Group %q): %s", id.Name, id.ResourceGroup, err)
 		}
 		if err := d.Set("input_mapping_fields", inputMappingFields); err != nil {
-			return fmt.Errorf("setting `input_schema_mapping_fields` for EventGrid Domain %q (Resource Group %q): %s", id.Name, id.ResourceGroup, err)
+			return fmt.Errorf("setting `input_mapping_fields` for EventGrid Domain %q (Resource Group %q): %s", id.Name, id.ResourceGroup, err)
 		}
 
 		inputMappingDefaultValues, err := flattenAzureRmEventgridDomainInputMappingDefaultValues(props.InputSchemaMapping)
 		if err != nil {
-			return fmt.Errorf("flattening `input_schema_mapping_default_values` for EventGrid Domain %q (Resource Group %q): %s", id.Name, id.ResourceGroup, err)
+			return fmt.Errorf("flattening `input_mapping_default_values` for EventGrid Domain %q (Resource Group %q): %s", id.Name, id.ResourceGroup, err)
 		}
 		if err := d.Set("input_mapping_default_values", inputMappingDefaultValues); err != nil {
-			return fmt.Errorf("setting `input_schema_mapping_fields` for EventGrid Domain %q (Resource Group %q): %s", id.Name, id.ResourceGroup, err)
+			return fmt.Errorf("setting `input_mapping_default_values` for EventGrid Domain %q (Resource Group %q): %s", id.Name, id.ResourceGroup, err)
 		}
 
 		publicNetworkAccessEnabled := flattenPublicNetworkAccess(props.PublicNetworkAccess)
@@ -287,7 +294,7 @@ func resourceEventGridDomainRead(d *pluginsdk.ResourceData, meta interface{}) er
 			return fmt.Errorf("setting `public_network_access_enabled` in EventGrid Domain %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
 		}
 
-		inboundIPRules := flattenInboundIPRules(props.InboundIPRules)
+		inboundIPRules := orderInboundIPRulesByConfig(d, flattenInboundIPRules(props.InboundIPRules))
 		if err := d.Set("inbound_ip_rule", inboundIPRules); err != nil {
 			return fmt.Errorf("setting `inbound_ip_rule` in EventGrid Domain %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
 		}
@@ -323,7 +330,9 @@ func resourceEventGridDomainDelete(d *pluginsdk.ResourceData, meta interface{})
 		return fmt.Errorf("deleting Event Grid Domain %q: %+v", id.Name, err)
 	}
 
-	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+	if features.EventGridSkipWaitingForDeletion() {
+		log.Printf("[DEBUG] Not waiting for the deletion of Event Grid Domain %q to complete as `ARM_EVENTGRID_SKIP_DELETION_WAIT` is set", id.Name)
+	} else if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
 		return fmt.Errorf("deleting Event Grid Domain %q: %+v", id.Name, err)
 	}
 
@@ -372,21 +381,31 @@ func expandAzureRmEventgridDomainInputMapping(d *pluginsdk.ResourceData) *eventg
 		}
 	}
 
+	// a field can be mapped from a source field and have a default value, so these are merged into the same field
 	if imdvok {
 		mappings := imdv.([]interface{})
 		if len(mappings) > 0 && mappings[0] != nil {
 			mapping := mappings[0].(map[string]interface{})
 
 			if dataVersion := mapping["data_version"].(string); dataVersion != "" {
-				jismp.DataVersion = &eventgrid.JSONFieldWithDefault{DefaultValue: &dataVersion}
+				if jismp.DataVersion == nil {
+					jismp.DataVersion = &eventgrid.JSONFieldWithDefault{}
+				}
+				jismp.DataVersion.DefaultValue = &dataVersion
 			}
 
 			if subject := mapping["subject"].(string); subject != "" {
-				jismp.Subject = &eventgrid.JSONFieldWithDefault{DefaultValue: &subject}
+				if jismp.Subject == nil {
+					jismp.Subject = &eventgrid.JSONFieldWithDefault{}
+				}
+				jismp.Subject.DefaultValue = &subject
 			}
 
 			if eventType := mapping["event_type"].(string); eventType != "" {
-				jismp.EventType = &eventgrid.JSONFieldWithDefault{DefaultValue: &eventType}
+				if jismp.EventType == nil {
+					jismp.EventType = &eventgrid.JSONFieldWithDefault{}
+				}
+				jismp.EventType.DefaultValue = &eventType
 			}
 		}
 	}
@@ -435,6 +454,10 @@ func flattenAzureRmEventgridDomainInputMapping(input eventgrid.BasicInputSchemaM
 		result["subject"] = *props.Subject.SourceField
 	}
 
+	if len(result) == 0 {
+		return []interface{}{}, nil
+	}
+
 	return []interface{}{result}, nil
 }
 
@@ -462,5 +485,9 @@ func flattenAzureRmEventgridDomainInputMappingDefaultValues(input eventgrid.Basi
 		result["subject"] = *props.Subject.DefaultValue
 	}
 
+	if len(result) == 0 {
+		return []interface{}{}, nil
+	}
+
 	return []interface{}{result}, nil
 }
@@ -26,6 +26,7 @@ func TestAccEventGridDomain_basic(t *testing.T) {
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
 				check.That(data.ResourceName).Key("endpoint").Exists(),
+				check.That(data.ResourceName).Key("metric_arm_resource_id").Exists(),
 				check.That(data.ResourceName).Key("primary_access_key").Exists(),
 				check.That(data.ResourceName).Key("secondary_access_key").Exists(),
 			),
@@ -71,6 +72,23 @@ func TestAccEventGridDomain_mapping(t *testing.T) {
 	})
 }
 
+func TestAccEventGridDomain_mappingFieldWithDefaultValue(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_eventgrid_domain", "test")
+	r := EventGridDomainResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.mappingFieldWithDefaultValue(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("input_mapping_fields.0.event_type").HasValue("eventType"),
+				check.That(data.ResourceName).Key("input_mapping_default_values.0.event_type").HasValue("DefaultEventType"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccEventGridDomain_basicWithTags(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_eventgrid_domain", "test")
 	r := EventGridDomainResource{}
@@ -225,6 +243,36 @@ resource "azurerm_eventgrid_domain" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
 }
 
+func (EventGridDomainResource) mappingFieldWithDefaultValue(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_eventgrid_domain" "test" {
+  name                = "acctesteg-%d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+
+  input_schema = "CustomEventSchema"
+
+  input_mapping_fields {
+    id         = "id"
+    event_type = "eventType"
+  }
+
+  input_mapping_default_values {
+    event_type = "DefaultEventType"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
+}
+
 func (EventGridDomainResource) basicWithTags(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
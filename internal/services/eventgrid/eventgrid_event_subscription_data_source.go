@@ -0,0 +1,94 @@
+package eventgrid
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func dataSourceEventGridEventSubscription() *pluginsdk.Resource {
+	// the attributes are the same as those set by the resource, so that the Read can be shared
+	dataSourceSchema := eventSubscriptionDataSourceSchema(resourceEventGridEventSubscription().Schema)
+
+	// this is only used to resolve `expiration_time_utc` when creating/updating the Event Subscription
+	delete(dataSourceSchema, "expiration_relative_to_now")
+
+	dataSourceSchema["name"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeString,
+		Required: true,
+	}
+
+	dataSourceSchema["scope"] = &pluginsdk.Schema{
+		Type:         pluginsdk.TypeString,
+		Required:     true,
+		ValidateFunc: azure.ValidateResourceID,
+	}
+
+	return &pluginsdk.Resource{
+		Read: dataSourceEventGridEventSubscriptionRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: dataSourceSchema,
+	}
+}
+
+func dataSourceEventGridEventSubscriptionRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).EventGrid.EventSubscriptionsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	name := d.Get("name").(string)
+	scope := d.Get("scope").(string)
+
+	resp, err := client.Get(ctx, scope, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("EventGrid Event Subscription %q (Scope %q) was not found", name, scope)
+		}
+
+		return fmt.Errorf("retrieving EventGrid Event Subscription %q (Scope %q): %+v", name, scope, err)
+	}
+	if resp.ID == nil || *resp.ID == "" {
+		return fmt.Errorf("retrieving EventGrid Event Subscription %q (Scope %q): `id` was nil", name, scope)
+	}
+
+	d.SetId(*resp.ID)
+
+	return resourceEventGridEventSubscriptionRead(d, meta)
+}
+
+// eventSubscriptionDataSourceSchema returns a copy of the resource schema where every attribute is Computed
+func eventSubscriptionDataSourceSchema(input map[string]*pluginsdk.Schema) map[string]*pluginsdk.Schema {
+	output := make(map[string]*pluginsdk.Schema, len(input))
+
+	for k, v := range input {
+		s := &pluginsdk.Schema{
+			Type:      v.Type,
+			Computed:  true,
+			Sensitive: v.Sensitive,
+		}
+
+		switch elem := v.Elem.(type) {
+		case *pluginsdk.Resource:
+			s.Elem = &pluginsdk.Resource{
+				Schema: eventSubscriptionDataSourceSchema(elem.Schema),
+			}
+		case *pluginsdk.Schema:
+			s.Elem = &pluginsdk.Schema{
+				Type: elem.Type,
+			}
+		}
+
+		output[k] = s
+	}
+
+	return output
+}
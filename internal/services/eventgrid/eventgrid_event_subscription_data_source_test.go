@@ -0,0 +1,68 @@
+package eventgrid_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type EventGridEventSubscriptionDataSource struct {
+}
+
+func TestAccEventGridEventSubscriptionDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_eventgrid_event_subscription", "test")
+	r := EventGridEventSubscriptionDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("event_delivery_schema").HasValue("EventGridSchema"),
+				check.That(data.ResourceName).Key("storage_queue_endpoint.#").HasValue("1"),
+				check.That(data.ResourceName).Key("storage_blob_dead_letter_destination.#").HasValue("1"),
+				check.That(data.ResourceName).Key("retry_policy.0.max_delivery_attempts").HasValue("11"),
+				check.That(data.ResourceName).Key("labels.#").HasValue("3"),
+			),
+		},
+	})
+}
+
+func TestAccEventGridEventSubscriptionDataSource_filter(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_eventgrid_event_subscription", "test")
+	r := EventGridEventSubscriptionDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.filter(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("included_event_types.#").HasValue("2"),
+				check.That(data.ResourceName).Key("subject_filter.0.subject_begins_with").HasValue("test/test"),
+				check.That(data.ResourceName).Key("subject_filter.0.subject_ends_with").HasValue(".jpg"),
+			),
+		},
+	})
+}
+
+func (EventGridEventSubscriptionDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_eventgrid_event_subscription" "test" {
+  name  = azurerm_eventgrid_event_subscription.test.name
+  scope = azurerm_eventgrid_event_subscription.test.scope
+}
+`, EventGridEventSubscriptionResource{}.basic(data))
+}
+
+func (EventGridEventSubscriptionDataSource) filter(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_eventgrid_event_subscription" "test" {
+  name  = azurerm_eventgrid_event_subscription.test.name
+  scope = azurerm_eventgrid_event_subscription.test.scope
+}
+`, EventGridEventSubscriptionResource{}.filter(data))
+}
@@ -1,13 +1,17 @@
 package eventgrid
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/preview/eventgrid/mgmt/2020-10-15-preview/eventgrid"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/eventgrid/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
@@ -43,27 +47,42 @@ func resourceEventGridEventSubscription() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
-		CustomizeDiff: pluginsdk.CustomizeDiffShim(eventSubscriptionCustomizeDiffAdvancedFilter),
-
-		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
-			_, err := parse.EventSubscriptionID(id)
-			return err
-		}),
+		CustomizeDiff: pluginsdk.CustomDiffWithAll(
+			pluginsdk.CustomizeDiffShim(eventSubscriptionCustomizeDiffAdvancedFilter),
+			pluginsdk.CustomizeDiffShim(eventSubscriptionCustomizeDiffDeadLetterIdentity),
+			pluginsdk.CustomizeDiffShim(eventSubscriptionCustomizeDiffIdentity),
+			pluginsdk.CustomizeDiffShim(eventSubscriptionCustomizeDiffEventDeliverySchema),
+			pluginsdk.CustomizeDiffShim(eventSubscriptionCustomizeDiffDeadLetterDeliverySchema),
+			pluginsdk.CustomizeDiffShim(eventSubscriptionCustomizeDiffDeliveryProperty),
+			pluginsdk.CustomizeDiffShim(eventSubscriptionCustomizeDiffExpirationRelativeToNow),
+			pluginsdk.CustomizeDiffShim(eventSubscriptionCustomizeDiffExpirationTimeUTC),
+			pluginsdk.CustomizeDiffShim(eventSubscriptionCustomizeDiffConfigHash(PossibleEventSubscriptionEndpointTypes(), "delivery_property")),
+		),
+
+		Importer: pluginsdk.ImporterValidatingResourceId(eventSubscriptionImportIDValidator(
+			parse.EventSubscriptionScopeTypeDomainTopic,
+			parse.EventSubscriptionScopeTypeResource,
+		)),
 
 		Schema: map[string]*pluginsdk.Schema{
 			"name": eventSubscriptionSchemaEventSubscriptionName(),
 
 			"scope": {
-				Type:         pluginsdk.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringIsNotEmpty,
+				Type:     pluginsdk.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.All(
+					azure.ValidateResourceID,
+					eventSubscriptionValidateScope,
+				),
 			},
 
 			"event_delivery_schema": eventSubscriptionSchemaEventDeliverySchema(),
 
 			"expiration_time_utc": eventSubscriptionSchemaExpirationTimeUTC(),
 
+			"expiration_relative_to_now": eventSubscriptionSchemaExpirationRelativeToNow(),
+
 			"topic_name": {
 				Type:       pluginsdk.TypeString,
 				Optional:   true,
@@ -71,68 +90,23 @@ func resourceEventGridEventSubscription() *pluginsdk.Resource {
 				Deprecated: "This field has been updated to readonly field since Apr 25, 2019 so no longer has any affect and will be removed in version 3.0 of the provider.",
 			},
 
-			"azure_function_endpoint": eventSubscriptionSchemaAzureFunctionEndpoint(
-				utils.RemoveFromStringArray(
-					PossibleEventSubscriptionEndpointTypes(),
-					string(AzureFunctionEndpoint),
-				),
-			),
+			"azure_function_endpoint": eventSubscriptionSchemaAzureFunctionEndpoint(PossibleEventSubscriptionEndpointTypes()),
 
-			"eventhub_endpoint_id": eventSubscriptionSchemaEventHubEndpointID(
-				utils.RemoveFromStringArray(
-					PossibleEventSubscriptionEndpointTypes(),
-					string(EventHubEndpointID),
-				),
-			),
+			"eventhub_endpoint_id": eventSubscriptionSchemaEventHubEndpointID(PossibleEventSubscriptionEndpointTypes()),
 
-			"eventhub_endpoint": eventSubscriptionSchemaEventHubEndpoint(
-				utils.RemoveFromStringArray(
-					PossibleEventSubscriptionEndpointTypes(),
-					string(EventHubEndpoint),
-				),
-			),
+			"eventhub_endpoint": eventSubscriptionSchemaEventHubEndpoint(PossibleEventSubscriptionEndpointTypes()),
 
-			"hybrid_connection_endpoint_id": eventSubscriptionSchemaHybridConnectionEndpointID(
-				utils.RemoveFromStringArray(
-					PossibleEventSubscriptionEndpointTypes(),
-					string(HybridConnectionEndpointID),
-				),
-			),
+			"hybrid_connection_endpoint_id": eventSubscriptionSchemaHybridConnectionEndpointID(PossibleEventSubscriptionEndpointTypes()),
 
-			"hybrid_connection_endpoint": eventSubscriptionSchemaHybridEndpoint(
-				utils.RemoveFromStringArray(
-					PossibleEventSubscriptionEndpointTypes(),
-					string(HybridConnectionEndpoint),
-				),
-			),
+			"hybrid_connection_endpoint": eventSubscriptionSchemaHybridEndpoint(PossibleEventSubscriptionEndpointTypes()),
 
-			"service_bus_queue_endpoint_id": eventSubscriptionSchemaServiceBusQueueEndpointID(
-				utils.RemoveFromStringArray(
-					PossibleEventSubscriptionEndpointTypes(),
-					string(ServiceBusQueueEndpointID),
-				),
-			),
+			"service_bus_queue_endpoint_id": eventSubscriptionSchemaServiceBusQueueEndpointID(PossibleEventSubscriptionEndpointTypes()),
 
-			"service_bus_topic_endpoint_id": eventSubscriptionSchemaServiceBusTopicEndpointID(
-				utils.RemoveFromStringArray(
-					PossibleEventSubscriptionEndpointTypes(),
-					string(ServiceBusTopicEndpointID),
-				),
-			),
+			"service_bus_topic_endpoint_id": eventSubscriptionSchemaServiceBusTopicEndpointID(PossibleEventSubscriptionEndpointTypes()),
 
-			"storage_queue_endpoint": eventSubscriptionSchemaStorageQueueEndpoint(
-				utils.RemoveFromStringArray(
-					PossibleEventSubscriptionEndpointTypes(),
-					string(StorageQueueEndpoint),
-				),
-			),
+			"storage_queue_endpoint": eventSubscriptionSchemaStorageQueueEndpoint(PossibleEventSubscriptionEndpointTypes()),
 
-			"webhook_endpoint": eventSubscriptionSchemaWebHookEndpoint(
-				utils.RemoveFromStringArray(
-					PossibleEventSubscriptionEndpointTypes(),
-					string(WebHookEndpoint),
-				),
-			),
+			"webhook_endpoint": eventSubscriptionSchemaWebHookEndpoint(PossibleEventSubscriptionEndpointTypes()),
 
 			"included_event_types": eventSubscriptionSchemaIncludedEventTypes(),
 
@@ -152,6 +126,11 @@ func resourceEventGridEventSubscription() *pluginsdk.Resource {
 
 			"advanced_filtering_on_arrays_enabled": eventSubscriptionSchemaEnableAdvancedFilteringOnArrays(),
 
+			"config_hash": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"delivery_property": eventSubscriptionSchemaDeliveryProperty(),
 		},
 	}
@@ -178,9 +157,18 @@ func resourceEventGridEventSubscriptionCreateUpdate(d *pluginsdk.ResourceData, m
 		}
 	}
 
+	if d.IsNewResource() || d.HasChange("event_delivery_schema") {
+		if err := eventSubscriptionValidateDomainInputSchema(ctx, meta.(*clients.Client).EventGrid.DomainsClient, scope, d.Get("event_delivery_schema").(string)); err != nil {
+			return err
+		}
+	}
+
 	destination := expandEventGridEventSubscriptionDestination(d)
 	if destination == nil {
-		return fmt.Errorf("One of the following endpoint types must be specificed to create an EventGrid Event Subscription: %q", PossibleEventSubscriptionEndpointTypes())
+		return EventSubscriptionEndpointNotSpecifiedError{
+			ResourceType:  "EventGrid Event Subscription",
+			EndpointTypes: PossibleEventSubscriptionEndpointTypes(),
+		}
 	}
 
 	filter, err := expandEventGridEventSubscriptionFilter(d)
@@ -203,29 +191,19 @@ func resourceEventGridEventSubscriptionCreateUpdate(d *pluginsdk.ResourceData, m
 		ExpirationTimeUtc:   expirationTime,
 	}
 
-	if v, ok := d.GetOk("delivery_identity"); ok {
-		deliveryIdentityRaw := v.([]interface{})
-		deliveryIdentity, err := expandEventGridEventSubscriptionIdentity(deliveryIdentityRaw)
-		if err != nil {
-			return fmt.Errorf("expanding `delivery_identity`: %+v", err)
-		}
-
-		eventSubscriptionProperties.DeliveryWithResourceIdentity = &eventgrid.DeliveryWithResourceIdentity{
-			Identity:    deliveryIdentity,
-			Destination: destination,
-		}
-	} else {
-		eventSubscriptionProperties.Destination = destination
+	eventSubscriptionProperties.Destination, eventSubscriptionProperties.DeliveryWithResourceIdentity, err = expandEventGridEventSubscriptionDeliveryDestination(d, destination)
+	if err != nil {
+		return fmt.Errorf("expanding `delivery_identity`: %w", err)
 	}
 
 	if v, ok := d.GetOk("dead_letter_identity"); ok {
 		if deadLetterDestination == nil {
-			return fmt.Errorf("`dead_letter_identity`: `storage_blob_dead_letter_destination` must be specified")
+			return ErrEventSubscriptionDeadLetterDestinationNotSpecified
 		}
 		deadLetterIdentityRaw := v.([]interface{})
 		deadLetterIdentity, err := expandEventGridEventSubscriptionIdentity(deadLetterIdentityRaw)
 		if err != nil {
-			return fmt.Errorf("expanding `dead_letter_identity`: %+v", err)
+			return fmt.Errorf("expanding `dead_letter_identity`: %w", err)
 		}
 
 		eventSubscriptionProperties.DeadLetterWithResourceIdentity = &eventgrid.DeadLetterWithResourceIdentity{
@@ -240,7 +218,8 @@ func resourceEventGridEventSubscriptionCreateUpdate(d *pluginsdk.ResourceData, m
 		EventSubscriptionProperties: &eventSubscriptionProperties,
 	}
 
-	log.Printf("[INFO] preparing arguments for AzureRM EventGrid Event Subscription creation with Properties: %+v.", eventSubscription)
+	// the payload isn't logged since the delivery properties and webhook url can contain secrets
+	log.Printf("[INFO] preparing arguments for AzureRM EventGrid Event Subscription %q (Scope %q) creation.", name, scope)
 
 	future, err := client.CreateOrUpdate(ctx, scope, name, eventSubscription)
 	if err != nil {
@@ -251,7 +230,9 @@ func resourceEventGridEventSubscriptionCreateUpdate(d *pluginsdk.ResourceData, m
 		return fmt.Errorf("waiting for EventGrid Event Subscription %q (Scope %q) to become available: %s", name, scope, err)
 	}
 
-	read, err := client.Get(ctx, scope, name)
+	read, err := eventSubscriptionGetAfterCreate(func() (eventgrid.EventSubscription, error) {
+		return client.Get(ctx, scope, name)
+	})
 	if err != nil {
 		return fmt.Errorf("retrieving EventGrid Event Subscription %q (Scope %q): %s", name, scope, err)
 	}
@@ -259,6 +240,7 @@ func resourceEventGridEventSubscriptionCreateUpdate(d *pluginsdk.ResourceData, m
 		return fmt.Errorf("Cannot read EventGrid Event Subscription %s (Scope %s) ID", name, scope)
 	}
 
+	meta.(*clients.Client).EventGrid.RemoveWebhookFullURLFromCache(*read.ID)
 	d.SetId(*read.ID)
 
 	return resourceEventGridEventSubscriptionRead(d, meta)
@@ -295,16 +277,15 @@ func resourceEventGridEventSubscriptionRead(d *pluginsdk.ResourceData, meta inte
 
 		d.Set("event_delivery_schema", string(props.EventDeliverySchema))
 
-		destination := props.Destination
-		deliveryIdentityFlattened := make([]interface{}, 0)
-		if deliveryIdentity := props.DeliveryWithResourceIdentity; deliveryIdentity != nil {
-			destination = deliveryIdentity.Destination
-			deliveryIdentityFlattened = flattenEventGridEventSubscriptionIdentity(deliveryIdentity.Identity)
-		}
+		destination, deliveryIdentityFlattened := flattenEventGridEventSubscriptionDeliveryDestination(props)
 		if err := d.Set("delivery_identity", deliveryIdentityFlattened); err != nil {
 			return fmt.Errorf("setting `delivery_identity` for EventGrid Event Subscription %q (Scope %q): %s", id.Name, id.Scope, err)
 		}
 
+		if err := resetEventGridEventSubscriptionEndpoints(d, destination, PossibleEventSubscriptionEndpointTypes()); err != nil {
+			return fmt.Errorf("resetting the endpoints for EventGrid Event Subscription %q (Scope %q): %+v", id.Name, id.Scope, err)
+		}
+
 		if azureFunctionEndpoint, ok := destination.AsAzureFunctionEventSubscriptionDestination(); ok {
 			if err := d.Set("azure_function_endpoint", flattenEventGridEventSubscriptionAzureFunctionEndpoint(azureFunctionEndpoint)); err != nil {
 				return fmt.Errorf("setting `%q` for EventGrid Event Subscription %q (Scope %q): %s", "azure_function_endpoint", id.Name, id.Scope, err)
@@ -367,11 +348,15 @@ func resourceEventGridEventSubscriptionRead(d *pluginsdk.ResourceData, meta inte
 			}
 		}
 		if v, ok := destination.AsWebHookEventSubscriptionDestination(); ok {
-			fullURL, err := client.GetFullURL(ctx, id.Scope, id.Name)
+			fullURL, err := eventSubscriptionWebhookFullURL(v, func() (eventgrid.EventSubscriptionFullURL, error) {
+				return meta.(*clients.Client).EventGrid.WebhookFullURL(d.Id(), func() (eventgrid.EventSubscriptionFullURL, error) {
+					return client.GetFullURL(ctx, id.Scope, id.Name)
+				})
+			})
 			if err != nil {
-				return fmt.Errorf("making Read request on EventGrid Event Subscription full URL '%s': %+v", id.Name, err)
+				return fmt.Errorf("retrieving the full url of the `webhook_endpoint` for EventGrid Event Subscription %q (Scope %q): %+v", id.Name, id.Scope, err)
 			}
-			if err := d.Set("webhook_endpoint", flattenEventGridEventSubscriptionWebhookEndpoint(v, &fullURL)); err != nil {
+			if err := d.Set("webhook_endpoint", flattenEventGridEventSubscriptionWebhookEndpoint(v, fullURL, d.Get("webhook_endpoint.0.url").(string))); err != nil {
 				return fmt.Errorf("setting `%q` for EventGrid Event Subscription %q (Scope %q): %s", "webhook_endpoint", id.Name, id.Scope, err)
 			}
 
@@ -402,11 +387,15 @@ func resourceEventGridEventSubscriptionRead(d *pluginsdk.ResourceData, meta inte
 
 		if filter := props.Filter; filter != nil {
 			d.Set("included_event_types", filter.IncludedEventTypes)
-			d.Set("advanced_filtering_on_arrays_enabled", filter.EnableAdvancedFilteringOnArrays)
-			if err := d.Set("subject_filter", flattenEventGridEventSubscriptionSubjectFilter(filter)); err != nil {
+			d.Set("advanced_filtering_on_arrays_enabled", flattenEventGridEventSubscriptionEnableAdvancedFilteringOnArrays(filter))
+			if err := d.Set("subject_filter", flattenEventGridEventSubscriptionSubjectFilter(d, filter)); err != nil {
 				return fmt.Errorf("setting `subject_filter` for EventGrid Event Subscription %q (Scope %q): %s", id.Name, id.Scope, err)
 			}
-			if err := d.Set("advanced_filter", flattenEventGridEventSubscriptionAdvancedFilter(filter)); err != nil {
+			advancedFilter, err := flattenEventGridEventSubscriptionAdvancedFilter(d, filter)
+			if err != nil {
+				return fmt.Errorf("flattening `advanced_filter` for EventGrid Event Subscription %q (Scope %q): %+v", id.Name, id.Scope, err)
+			}
+			if err := d.Set("advanced_filter", advancedFilter); err != nil {
 				return fmt.Errorf("setting `advanced_filter` for EventGrid Event Subscription %q (Scope %q): %s", id.Name, id.Scope, err)
 			}
 		}
@@ -419,17 +408,22 @@ func resourceEventGridEventSubscriptionRead(d *pluginsdk.ResourceData, meta inte
 			}
 		}
 
-		if retryPolicy := props.RetryPolicy; retryPolicy != nil {
-			if err := d.Set("retry_policy", flattenEventGridEventSubscriptionRetryPolicy(retryPolicy)); err != nil {
-				return fmt.Errorf("setting `retry_policy` for EventGrid Event Subscription %q (Scope %q): %s", id.Name, id.Scope, err)
-			}
+		// this is set even when the API omits the retry policy, using the service defaults, so that reads are consistent
+		if err := d.Set("retry_policy", flattenEventGridEventSubscriptionRetryPolicy(props.RetryPolicy)); err != nil {
+			return fmt.Errorf("setting `retry_policy` for EventGrid Event Subscription %q (Scope %q): %s", id.Name, id.Scope, err)
 		}
 
-		if err := d.Set("labels", props.Labels); err != nil {
+		if err := d.Set("labels", flattenEventGridEventSubscriptionLabels(d, props.Labels)); err != nil {
 			return fmt.Errorf("setting `labels` for EventGrid Event Subscription %q (Scope %q): %s", id.Name, id.Scope, err)
 		}
 	}
 
+	configHash, err := eventSubscriptionConfigHash(d, PossibleEventSubscriptionEndpointTypes())
+	if err != nil {
+		return fmt.Errorf("hashing the configuration of EventGrid Event Subscription %q (Scope %q): %+v", id.Name, id.Scope, err)
+	}
+	d.Set("config_hash", configHash)
+
 	return nil
 }
 
@@ -448,9 +442,88 @@ func resourceEventGridEventSubscriptionDelete(d *pluginsdk.ResourceData, meta in
 		return fmt.Errorf("deleting %s: %+v", *id, err)
 	}
 
-	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+	if features.EventGridSkipWaitingForDeletion() {
+		log.Printf("[DEBUG] Not waiting for the deletion of %s to complete as `ARM_EVENTGRID_SKIP_DELETION_WAIT` is set", *id)
+	} else if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
 		return fmt.Errorf("waiting for the deletion of %s: %+v", *id, err)
 	}
 
+	meta.(*clients.Client).EventGrid.RemoveWebhookFullURLFromCache(d.Id())
+
+	return nil
+}
+
+// eventSubscriptionValidateDomainInputSchema checks that a subscription to an EventGrid Domain (or a Domain Topic) uses
+// an event delivery schema which events published using the Domain's input schema can be delivered with. This needs to
+// look up the Domain, so it's checked when creating the subscription rather than during the plan.
+func eventSubscriptionValidateDomainInputSchema(ctx context.Context, client *eventgrid.DomainsClient, scope string, deliverySchema string) error {
+	var domainId parse.DomainId
+	if id, err := parse.DomainTopicID(scope); err == nil {
+		domainId = parse.NewDomainID(id.SubscriptionId, id.ResourceGroup, id.DomainName)
+	} else if id, err := parse.DomainID(scope); err == nil {
+		domainId = *id
+	} else {
+		return nil
+	}
+
+	resp, err := client.Get(ctx, domainId.ResourceGroup, domainId.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", domainId, err)
+	}
+
+	if props := resp.DomainProperties; props != nil {
+		return eventSubscriptionValidateDeliverySchemaForInputSchema(string(props.InputSchema), deliverySchema)
+	}
+
 	return nil
 }
+
+// eventSubscriptionScopeResourceTypes are the (lower-cased) types of resource which an Event Subscription can be
+// scoped to, in addition to the sources of System Topics
+var eventSubscriptionScopeResourceTypes = map[string]bool{
+	"microsoft.apimanagement/service":     true,
+	"microsoft.cache/redis":               true,
+	"microsoft.eventgrid/domains/topics":  true,
+	"microsoft.healthcareapis/workspaces": true,
+}
+
+// eventSubscriptionValidateScope checks that the `scope` is a Subscription, Resource Group or a type of resource which
+// is known to support Event Subscriptions. Since new types of resource can support Event Subscriptions before they're
+// known to the provider, other types of resource are only logged and left for the API to validate.
+func eventSubscriptionValidateScope(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	if !eventSubscriptionScopeIsKnown(v) {
+		log.Printf("[WARN] %q isn't the ID of a Subscription, Resource Group or a type of resource which is known to support Event Subscriptions: %q", k, v)
+	}
+
+	return
+}
+
+// eventSubscriptionScopeIsKnown returns whether the scope is the ID of a Subscription, Resource Group or a type of
+// resource which is known to support Event Subscriptions
+func eventSubscriptionScopeIsKnown(scope string) bool {
+	resourceType := eventGridResourceTypeFromID(scope)
+	if resourceType == "" {
+		return false
+	}
+
+	if eventSubscriptionScopeResourceTypes[strings.ToLower(resourceType)] {
+		return true
+	}
+	for _, sourceResourceType := range eventGridSystemTopicSourceResourceTypes {
+		if strings.EqualFold(resourceType, sourceResourceType) {
+			return true
+		}
+	}
+
+	return false
+}
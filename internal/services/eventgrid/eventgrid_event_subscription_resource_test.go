@@ -3,6 +3,7 @@ package eventgrid_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -50,6 +51,96 @@ func TestAccEventGridEventSubscription_requiresImport(t *testing.T) {
 	})
 }
 
+func TestAccEventGridEventSubscription_labelsUpdate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_eventgrid_event_subscription", "test")
+	r := EventGridEventSubscriptionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.labels(data, `labels = ["test1"]`),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("labels.#").HasValue("1"),
+				check.That(data.ResourceName).Key("labels.0").HasValue("test1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.labels(data, `labels = ["test2", "test3"]`),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("labels.#").HasValue("2"),
+				check.That(data.ResourceName).Key("labels.0").HasValue("test2"),
+				check.That(data.ResourceName).Key("labels.1").HasValue("test3"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.labels(data, ""),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("labels.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccEventGridEventSubscription_retryPolicyMaxDeliveryAttemptsUpdate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_eventgrid_event_subscription", "test")
+	r := EventGridEventSubscriptionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.retryPolicy(data, 5, 60),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("retry_policy.0.max_delivery_attempts").HasValue("5"),
+				check.That(data.ResourceName).Key("retry_policy.0.event_time_to_live").HasValue("60"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.retryPolicy(data, 30, 60),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("retry_policy.0.max_delivery_attempts").HasValue("30"),
+				check.That(data.ResourceName).Key("retry_policy.0.event_time_to_live").HasValue("60"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.retryPolicy(data, 1, 1),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("retry_policy.0.max_delivery_attempts").HasValue("1"),
+				check.That(data.ResourceName).Key("retry_policy.0.event_time_to_live").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccEventGridEventSubscription_domainInputSchema(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_eventgrid_event_subscription", "test")
+	r := EventGridEventSubscriptionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			// the Domain's input schema is checked when the Event Subscription is created
+			Config:      r.domainInputSchema(data, "EventGridSchema"),
+			ExpectError: regexp.MustCompile("an `event_delivery_schema` of \"EventGridSchema\" isn't supported"),
+		},
+		{
+			Config: r.domainInputSchema(data, "CloudEventSchemaV1_0"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccEventGridEventSubscription_eventHubID(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_eventgrid_event_subscription", "test")
 	r := EventGridEventSubscriptionResource{}
@@ -138,6 +229,80 @@ func TestAccEventGridEventSubscription_update(t *testing.T) {
 	})
 }
 
+func TestAccEventGridEventSubscription_eventDeliverySchemaUpdate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_eventgrid_event_subscription", "test")
+	r := EventGridEventSubscriptionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.eventDeliverySchema(data, "EventGridSchema"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("event_delivery_schema").HasValue("EventGridSchema"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.eventDeliverySchema(data, "CloudEventSchemaV1_0"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("event_delivery_schema").HasValue("CloudEventSchemaV1_0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccEventGridEventSubscription_deadLetterDestinationUpdate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_eventgrid_event_subscription", "test")
+	r := EventGridEventSubscriptionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.deadLetterDestination(data, "first"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				acceptance.TestCheckResourceAttrPair(data.ResourceName, "storage_blob_dead_letter_destination.0.storage_account_id", "azurerm_storage_account.first", "id"),
+				check.That(data.ResourceName).Key("storage_blob_dead_letter_destination.0.storage_blob_container_name").HasValue("deadletter-first"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.deadLetterDestination(data, "second"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				acceptance.TestCheckResourceAttrPair(data.ResourceName, "storage_blob_dead_letter_destination.0.storage_account_id", "azurerm_storage_account.second", "id"),
+				check.That(data.ResourceName).Key("storage_blob_dead_letter_destination.0.storage_blob_container_name").HasValue("deadletter-second"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccEventGridEventSubscription_includedEventTypesExplicitlyEmpty(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_eventgrid_event_subscription", "test")
+	r := EventGridEventSubscriptionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.includedEventTypes(data, `["Microsoft.Resources.ResourceWriteSuccess"]`),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("included_event_types.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.includedEventTypes(data, "[]"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("included_event_types.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccEventGridEventSubscription_filter(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_eventgrid_event_subscription", "test")
 	r := EventGridEventSubscriptionResource{}
@@ -189,6 +354,29 @@ func TestAccEventGridEventSubscription_advancedFilterMaxItems(t *testing.T) {
 	})
 }
 
+func TestAccEventGridEventSubscription_advancedFilterMultipleStringBeginsWith(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_eventgrid_event_subscription", "test")
+	r := EventGridEventSubscriptionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.advancedFilterMultipleStringBeginsWith(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("advanced_filter.0.string_begins_with.#").HasValue("3"),
+				check.That(data.ResourceName).Key("advanced_filter.0.string_begins_with.0.key").HasValue("subject"),
+				check.That(data.ResourceName).Key("advanced_filter.0.string_begins_with.1.key").HasValue("data.key1"),
+				check.That(data.ResourceName).Key("advanced_filter.0.string_begins_with.2.key").HasValue("data.key2"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config:   r.advancedFilterMultipleStringBeginsWith(data),
+			PlanOnly: true,
+		},
+	})
+}
+
 func TestAccEventGridEventSubscription_deliveryPropertiesStatic(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_eventgrid_event_subscription", "test")
 	r := EventGridEventSubscriptionResource{}
@@ -396,6 +584,7 @@ func TestAccEventGridEventSubscription_userIdentity(t *testing.T) {
 				check.That(data.ResourceName).Key("delivery_identity.0.type").HasValue("UserAssigned"),
 				check.That(data.ResourceName).Key("dead_letter_identity.#").HasValue("1"),
 				check.That(data.ResourceName).Key("dead_letter_identity.0.type").HasValue("UserAssigned"),
+				check.That(data.ResourceName).Key("dead_letter_identity.0.user_assigned_identity").Exists(),
 			),
 		},
 		data.ImportStep(),
@@ -416,6 +605,66 @@ func TestAccEventGridEventSubscription_userIdentity(t *testing.T) {
 				check.That(data.ResourceName).Key("delivery_identity.0.type").HasValue("UserAssigned"),
 				check.That(data.ResourceName).Key("dead_letter_identity.#").HasValue("1"),
 				check.That(data.ResourceName).Key("dead_letter_identity.0.type").HasValue("UserAssigned"),
+				check.That(data.ResourceName).Key("dead_letter_identity.0.user_assigned_identity").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccEventGridEventSubscription_serviceBusTopicUserIdentityWithDeliveryProperties(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_eventgrid_event_subscription", "test")
+	r := EventGridEventSubscriptionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.serviceBusTopicUserIdentityWithDeliveryProperties(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("service_bus_topic_endpoint_id").Exists(),
+				check.That(data.ResourceName).Key("delivery_identity.#").HasValue("1"),
+				check.That(data.ResourceName).Key("delivery_identity.0.type").HasValue("UserAssigned"),
+				check.That(data.ResourceName).Key("delivery_property.#").HasValue("2"),
+				check.That(data.ResourceName).Key("delivery_property.0.header_name").HasValue("test-static-1"),
+				check.That(data.ResourceName).Key("delivery_property.0.value").HasValue("1"),
+				check.That(data.ResourceName).Key("delivery_property.1.header_name").HasValue("test-dynamic-1"),
+				check.That(data.ResourceName).Key("delivery_property.1.source_field").HasValue("data.system"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccEventGridEventSubscription_deliveryIdentityUpdate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_eventgrid_event_subscription", "test")
+	r := EventGridEventSubscriptionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.systemIdentity(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("delivery_identity.#").HasValue("1"),
+				check.That(data.ResourceName).Key("delivery_identity.0.type").HasValue("SystemAssigned"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.userIdentity(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("delivery_identity.#").HasValue("1"),
+				check.That(data.ResourceName).Key("delivery_identity.0.type").HasValue("UserAssigned"),
+				check.That(data.ResourceName).Key("delivery_identity.0.user_assigned_identity").Exists(),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("delivery_identity.#").HasValue("0"),
+				check.That(data.ResourceName).Key("storage_queue_endpoint.#").HasValue("1"),
 			),
 		},
 		data.ImportStep(),
@@ -480,28 +729,154 @@ resource "azurerm_storage_blob" "test" {
   size = 5120
 }
 
-resource "azurerm_eventgrid_event_subscription" "test" {
-  name  = "acctesteg-%[1]d"
-  scope = azurerm_resource_group.test.id
-
-  storage_queue_endpoint {
-    storage_account_id = azurerm_storage_account.test.id
-    queue_name         = azurerm_storage_queue.test.name
-  }
+resource "azurerm_eventgrid_event_subscription" "test" {
+  name  = "acctesteg-%[1]d"
+  scope = azurerm_resource_group.test.id
+
+  storage_queue_endpoint {
+    storage_account_id = azurerm_storage_account.test.id
+    queue_name         = azurerm_storage_queue.test.name
+  }
+
+  storage_blob_dead_letter_destination {
+    storage_account_id          = azurerm_storage_account.test.id
+    storage_blob_container_name = azurerm_storage_container.test.name
+  }
+
+  retry_policy {
+    event_time_to_live    = 11
+    max_delivery_attempts = 11
+  }
+
+  labels = ["test", "test1", "test2"]
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
+func (EventGridEventSubscriptionResource) labels(data acceptance.TestData, labels string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-eg-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_queue" "test" {
+  name                 = "mysamplequeue-%[1]d"
+  storage_account_name = azurerm_storage_account.test.name
+}
+
+resource "azurerm_eventgrid_event_subscription" "test" {
+  name  = "acctesteg-%[1]d"
+  scope = azurerm_resource_group.test.id
+
+  storage_queue_endpoint {
+    storage_account_id = azurerm_storage_account.test.id
+    queue_name         = azurerm_storage_queue.test.name
+  }
+
+  %[4]s
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, labels)
+}
+
+func (EventGridEventSubscriptionResource) retryPolicy(data acceptance.TestData, maxDeliveryAttempts int, eventTimeToLive int) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-eg-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_queue" "test" {
+  name                 = "mysamplequeue-%[1]d"
+  storage_account_name = azurerm_storage_account.test.name
+}
+
+resource "azurerm_eventgrid_event_subscription" "test" {
+  name  = "acctesteg-%[1]d"
+  scope = azurerm_resource_group.test.id
+
+  storage_queue_endpoint {
+    storage_account_id = azurerm_storage_account.test.id
+    queue_name         = azurerm_storage_queue.test.name
+  }
+
+  retry_policy {
+    max_delivery_attempts = %[4]d
+    event_time_to_live    = %[5]d
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, maxDeliveryAttempts, eventTimeToLive)
+}
+
+func (EventGridEventSubscriptionResource) domainInputSchemaTemplate(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-eg-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_servicebus_namespace" "test" {
+  name                = "acctestservicebusnamespace-%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  sku                 = "Basic"
+}
+
+resource "azurerm_servicebus_queue" "test" {
+  name                = "acctestservicebusqueue-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  namespace_name      = azurerm_servicebus_namespace.test.name
+  enable_partitioning = true
+}
 
-  storage_blob_dead_letter_destination {
-    storage_account_id          = azurerm_storage_account.test.id
-    storage_blob_container_name = azurerm_storage_container.test.name
-  }
+resource "azurerm_eventgrid_domain" "test" {
+  name                = "acctesteg-%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  input_schema        = "CloudEventSchemaV1_0"
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
 
-  retry_policy {
-    event_time_to_live    = 11
-    max_delivery_attempts = 11
-  }
+func (r EventGridEventSubscriptionResource) domainInputSchema(data acceptance.TestData, eventDeliverySchema string) string {
+	return fmt.Sprintf(`
+%s
 
-  labels = ["test", "test1", "test2"]
+resource "azurerm_eventgrid_event_subscription" "test" {
+  name                          = "acctesteg-%d"
+  scope                         = azurerm_eventgrid_domain.test.id
+  event_delivery_schema         = "%s"
+  service_bus_queue_endpoint_id = azurerm_servicebus_queue.test.id
 }
-`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+`, r.domainInputSchemaTemplate(data), data.RandomInteger, eventDeliverySchema)
 }
 
 func (EventGridEventSubscriptionResource) requiresImport(data acceptance.TestData) string {
@@ -688,6 +1063,149 @@ resource "azurerm_eventgrid_event_subscription" "test" {
 `, data.RandomInteger, data.Locations.Primary)
 }
 
+func (EventGridEventSubscriptionResource) eventDeliverySchema(data acceptance.TestData, eventDeliverySchema string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-eg-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_queue" "test" {
+  name                 = "mysamplequeue-%[1]d"
+  storage_account_name = azurerm_storage_account.test.name
+}
+
+resource "azurerm_eventgrid_event_subscription" "test" {
+  name                  = "acctesteg-%[1]d"
+  scope                 = azurerm_resource_group.test.id
+  event_delivery_schema = "%[4]s"
+
+  storage_queue_endpoint {
+    storage_account_id = azurerm_storage_account.test.id
+    queue_name         = azurerm_storage_queue.test.name
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, eventDeliverySchema)
+}
+
+func (EventGridEventSubscriptionResource) deadLetterDestination(data acceptance.TestData, deadLetterDestination string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-eg-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_queue" "test" {
+  name                 = "mysamplequeue-%[1]d"
+  storage_account_name = azurerm_storage_account.test.name
+}
+
+resource "azurerm_storage_account" "first" {
+  name                     = "acctestdl1%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "first" {
+  name                  = "deadletter-first"
+  storage_account_name  = azurerm_storage_account.first.name
+  container_access_type = "private"
+}
+
+resource "azurerm_storage_account" "second" {
+  name                     = "acctestdl2%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "second" {
+  name                  = "deadletter-second"
+  storage_account_name  = azurerm_storage_account.second.name
+  container_access_type = "private"
+}
+
+resource "azurerm_eventgrid_event_subscription" "test" {
+  name  = "acctesteg-%[1]d"
+  scope = azurerm_resource_group.test.id
+
+  storage_queue_endpoint {
+    storage_account_id = azurerm_storage_account.test.id
+    queue_name         = azurerm_storage_queue.test.name
+  }
+
+  storage_blob_dead_letter_destination {
+    storage_account_id          = azurerm_storage_account.%[4]s.id
+    storage_blob_container_name = azurerm_storage_container.%[4]s.name
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, deadLetterDestination)
+}
+
+func (EventGridEventSubscriptionResource) includedEventTypes(data acceptance.TestData, includedEventTypes string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-eg-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_queue" "test" {
+  name                 = "mysamplequeue-%[1]d"
+  storage_account_name = azurerm_storage_account.test.name
+}
+
+resource "azurerm_eventgrid_event_subscription" "test" {
+  name                 = "acctesteg-%[1]d"
+  scope                = azurerm_resource_group.test.id
+  included_event_types = %[4]s
+
+  storage_queue_endpoint {
+    storage_account_id = azurerm_storage_account.test.id
+    queue_name         = azurerm_storage_queue.test.name
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, includedEventTypes)
+}
+
 func (EventGridEventSubscriptionResource) filter(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -959,6 +1477,59 @@ resource "azurerm_eventgrid_event_subscription" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomString)
 }
 
+func (EventGridEventSubscriptionResource) advancedFilterMultipleStringBeginsWith(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-eg-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_queue" "test" {
+  name                 = "mysamplequeue-%[1]d"
+  storage_account_name = azurerm_storage_account.test.name
+}
+
+resource "azurerm_eventgrid_event_subscription" "test" {
+  name  = "acctesteg-%[1]d"
+  scope = azurerm_storage_account.test.id
+
+  storage_queue_endpoint {
+    storage_account_id = azurerm_storage_account.test.id
+    queue_name         = azurerm_storage_queue.test.name
+  }
+
+  advanced_filter {
+    string_begins_with {
+      key    = "subject"
+      values = ["foo"]
+    }
+
+    string_begins_with {
+      key    = "data.key1"
+      values = ["bar", "baz"]
+    }
+
+    string_begins_with {
+      key    = "data.key2"
+      values = ["qux"]
+    }
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
 func (EventGridEventSubscriptionResource) systemIdentity(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -1087,6 +1658,62 @@ resource "azurerm_eventgrid_event_subscription" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomString)
 }
 
+func (EventGridEventSubscriptionResource) serviceBusTopicUserIdentityWithDeliveryProperties(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-eg-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_servicebus_namespace" "example" {
+  name                = "acctestservicebusnamespace-%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  sku                 = "Standard"
+}
+
+resource "azurerm_servicebus_topic" "test" {
+  name                = "acctestservicebustopic-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  namespace_name      = azurerm_servicebus_namespace.example.name
+  enable_partitioning = true
+}
+
+resource "azurerm_user_assigned_identity" "test" {
+  name                = "acctestUAI-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}
+
+resource "azurerm_eventgrid_event_subscription" "test" {
+  name                          = "acctesteg-%[1]d"
+  scope                         = azurerm_resource_group.test.id
+  service_bus_topic_endpoint_id = azurerm_servicebus_topic.test.id
+
+  delivery_identity {
+    type                   = "UserAssigned"
+    user_assigned_identity = azurerm_user_assigned_identity.test.id
+  }
+
+  delivery_property {
+    header_name = "test-static-1"
+    type        = "Static"
+    value       = "1"
+  }
+
+  delivery_property {
+    header_name  = "test-dynamic-1"
+    type         = "Dynamic"
+    source_field = "data.system"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
 func (EventGridEventSubscriptionResource) deliveryProperties(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -0,0 +1,162 @@
+package eventgrid_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/eventgrid/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type EventGridPartnerNamespaceResource struct {
+}
+
+func TestAccEventGridPartnerNamespace_basic(t *testing.T) {
+	if os.Getenv("ARM_TEST_EVENTGRID_PARTNER_REGISTRATION_ID") == "" {
+		t.Skip("Skipping as ARM_TEST_EVENTGRID_PARTNER_REGISTRATION_ID is not specified")
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_eventgrid_partner_namespace", "test")
+	r := EventGridPartnerNamespaceResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("endpoint").Exists(),
+				check.That(data.ResourceName).Key("primary_access_key").Exists(),
+				check.That(data.ResourceName).Key("secondary_access_key").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccEventGridPartnerNamespace_requiresImport(t *testing.T) {
+	if os.Getenv("ARM_TEST_EVENTGRID_PARTNER_REGISTRATION_ID") == "" {
+		t.Skip("Skipping as ARM_TEST_EVENTGRID_PARTNER_REGISTRATION_ID is not specified")
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_eventgrid_partner_namespace", "test")
+	r := EventGridPartnerNamespaceResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config:      r.requiresImport(data),
+			ExpectError: acceptance.RequiresImportError("azurerm_eventgrid_partner_namespace"),
+		},
+	})
+}
+
+func TestAccEventGridPartnerNamespace_update(t *testing.T) {
+	if os.Getenv("ARM_TEST_EVENTGRID_PARTNER_REGISTRATION_ID") == "" {
+		t.Skip("Skipping as ARM_TEST_EVENTGRID_PARTNER_REGISTRATION_ID is not specified")
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_eventgrid_partner_namespace", "test")
+	r := EventGridPartnerNamespaceResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.tags(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
+				check.That(data.ResourceName).Key("tags.foo").HasValue("bar"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (EventGridPartnerNamespaceResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.PartnerNamespaceID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.EventGrid.PartnerNamespacesClient.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return utils.Bool(resp.PartnerNamespaceProperties != nil), nil
+}
+
+func (EventGridPartnerNamespaceResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-eg-%d"
+  location = "%s"
+}
+
+resource "azurerm_eventgrid_partner_namespace" "test" {
+  name                                    = "acctesteg-%d"
+  location                                = azurerm_resource_group.test.location
+  resource_group_name                     = azurerm_resource_group.test.name
+  partner_registration_fully_qualified_id = "%s"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, os.Getenv("ARM_TEST_EVENTGRID_PARTNER_REGISTRATION_ID"))
+}
+
+func (EventGridPartnerNamespaceResource) requiresImport(data acceptance.TestData) string {
+	template := EventGridPartnerNamespaceResource{}.basic(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_eventgrid_partner_namespace" "import" {
+  name                                    = azurerm_eventgrid_partner_namespace.test.name
+  location                                = azurerm_eventgrid_partner_namespace.test.location
+  resource_group_name                     = azurerm_eventgrid_partner_namespace.test.resource_group_name
+  partner_registration_fully_qualified_id = azurerm_eventgrid_partner_namespace.test.partner_registration_fully_qualified_id
+}
+`, template)
+}
+
+func (EventGridPartnerNamespaceResource) tags(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-eg-%d"
+  location = "%s"
+}
+
+resource "azurerm_eventgrid_partner_namespace" "test" {
+  name                                    = "acctesteg-%d"
+  location                                = azurerm_resource_group.test.location
+  resource_group_name                     = azurerm_resource_group.test.name
+  partner_registration_fully_qualified_id = "%s"
+
+  tags = {
+    "foo" = "bar"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, os.Getenv("ARM_TEST_EVENTGRID_PARTNER_REGISTRATION_ID"))
+}
@@ -0,0 +1,51 @@
+package eventgrid_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/eventgrid/parse"
+)
+
+type EventGridPartnerRegistrationDataSource struct {
+}
+
+func TestAccEventGridPartnerRegistrationDataSource_basic(t *testing.T) {
+	if os.Getenv("ARM_TEST_EVENTGRID_PARTNER_REGISTRATION_ID") == "" {
+		t.Skip("Skipping as ARM_TEST_EVENTGRID_PARTNER_REGISTRATION_ID is not specified")
+	}
+
+	data := acceptance.BuildTestData(t, "data.azurerm_eventgrid_partner_registration", "test")
+	r := EventGridPartnerRegistrationDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(t),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("location").Exists(),
+				check.That(data.ResourceName).Key("partner_name").Exists(),
+			),
+		},
+	})
+}
+
+func (EventGridPartnerRegistrationDataSource) basic(t *testing.T) string {
+	id, err := parse.PartnerRegistrationID(os.Getenv("ARM_TEST_EVENTGRID_PARTNER_REGISTRATION_ID"))
+	if err != nil {
+		t.Fatalf("parsing ARM_TEST_EVENTGRID_PARTNER_REGISTRATION_ID: %+v", err)
+	}
+
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_eventgrid_partner_registration" "test" {
+  name                = "%s"
+  resource_group_name = "%s"
+}
+`, id.Name, id.ResourceGroup)
+}
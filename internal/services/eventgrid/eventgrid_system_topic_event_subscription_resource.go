@@ -1,17 +1,21 @@
 package eventgrid
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/preview/eventgrid/mgmt/2020-10-15-preview/eventgrid"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/eventgrid/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/eventgrid/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
@@ -42,75 +46,55 @@ func resourceEventGridSystemTopicEventSubscription() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
-		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
-			_, err := parse.SystemTopicEventSubscriptionID(id)
-			return err
-		}),
+		CustomizeDiff: pluginsdk.CustomDiffWithAll(
+			pluginsdk.CustomizeDiffShim(eventGridSystemTopicEventSubscriptionCustomizeDiffSystemTopic),
+			pluginsdk.CustomizeDiffShim(eventSubscriptionCustomizeDiffAdvancedFilter),
+			pluginsdk.CustomizeDiffShim(eventSubscriptionCustomizeDiffDeadLetterIdentity),
+			pluginsdk.CustomizeDiffShim(eventSubscriptionCustomizeDiffIdentity),
+			pluginsdk.CustomizeDiffShim(eventSubscriptionCustomizeDiffEventDeliverySchema),
+			pluginsdk.CustomizeDiffShim(eventSubscriptionCustomizeDiffDeadLetterDeliverySchema),
+			pluginsdk.CustomizeDiffShim(eventSubscriptionCustomizeDiffExpirationRelativeToNow),
+			pluginsdk.CustomizeDiffShim(eventSubscriptionCustomizeDiffExpirationTimeUTC),
+			pluginsdk.CustomizeDiffShim(eventSubscriptionCustomizeDiffConfigHash(PossibleSystemTopicEventSubscriptionEndpointTypes())),
+		),
+
+		Importer: pluginsdk.ImporterValidatingResourceIdThen(eventSubscriptionImportIDValidator(
+			parse.EventSubscriptionScopeTypeSystemTopic,
+		), importEventGridSystemTopicEventSubscription),
 
 		Schema: map[string]*pluginsdk.Schema{
 			"name": eventSubscriptionSchemaEventSubscriptionName(),
 
+			// this can either be the name of the System Topic (alongside `resource_group_name`) or its ID
 			"system_topic": {
-				Type:         pluginsdk.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringIsNotEmpty,
+				Type:             pluginsdk.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validate.SystemTopicNameOrID,
+				DiffSuppressFunc: eventGridSystemTopicEventSubscriptionSuppressSystemTopicDiff,
 			},
 
-			"resource_group_name": azure.SchemaResourceGroupName(),
+			"resource_group_name": azure.SchemaResourceGroupNameOptionalComputed(),
 
 			"event_delivery_schema": eventSubscriptionSchemaEventDeliverySchema(),
 
 			"expiration_time_utc": eventSubscriptionSchemaExpirationTimeUTC(),
 
-			"azure_function_endpoint": eventSubscriptionSchemaAzureFunctionEndpoint(
-				utils.RemoveFromStringArray(
-					PossibleSystemTopicEventSubscriptionEndpointTypes(),
-					string(AzureFunctionEndpoint),
-				),
-			),
-
-			"eventhub_endpoint_id": eventSubscriptionSchemaEventHubEndpointID(
-				utils.RemoveFromStringArray(
-					PossibleSystemTopicEventSubscriptionEndpointTypes(),
-					string(EventHubEndpointID),
-				),
-			),
-
-			"hybrid_connection_endpoint_id": eventSubscriptionSchemaHybridConnectionEndpointID(
-				utils.RemoveFromStringArray(
-					PossibleSystemTopicEventSubscriptionEndpointTypes(),
-					string(HybridConnectionEndpointID),
-				),
-			),
-
-			"service_bus_queue_endpoint_id": eventSubscriptionSchemaServiceBusQueueEndpointID(
-				utils.RemoveFromStringArray(
-					PossibleSystemTopicEventSubscriptionEndpointTypes(),
-					string(ServiceBusQueueEndpointID),
-				),
-			),
-
-			"service_bus_topic_endpoint_id": eventSubscriptionSchemaServiceBusTopicEndpointID(
-				utils.RemoveFromStringArray(
-					PossibleSystemTopicEventSubscriptionEndpointTypes(),
-					string(ServiceBusTopicEndpointID),
-				),
-			),
-
-			"storage_queue_endpoint": eventSubscriptionSchemaStorageQueueEndpoint(
-				utils.RemoveFromStringArray(
-					PossibleSystemTopicEventSubscriptionEndpointTypes(),
-					string(StorageQueueEndpoint),
-				),
-			),
-
-			"webhook_endpoint": eventSubscriptionSchemaWebHookEndpoint(
-				utils.RemoveFromStringArray(
-					PossibleSystemTopicEventSubscriptionEndpointTypes(),
-					string(WebHookEndpoint),
-				),
-			),
+			"expiration_relative_to_now": eventSubscriptionSchemaExpirationRelativeToNow(),
+
+			"azure_function_endpoint": eventSubscriptionSchemaAzureFunctionEndpoint(PossibleSystemTopicEventSubscriptionEndpointTypes()),
+
+			"eventhub_endpoint_id": eventSubscriptionSchemaEventHubEndpointID(PossibleSystemTopicEventSubscriptionEndpointTypes()),
+
+			"hybrid_connection_endpoint_id": eventSubscriptionSchemaHybridConnectionEndpointID(PossibleSystemTopicEventSubscriptionEndpointTypes()),
+
+			"service_bus_queue_endpoint_id": eventSubscriptionSchemaServiceBusQueueEndpointID(PossibleSystemTopicEventSubscriptionEndpointTypes()),
+
+			"service_bus_topic_endpoint_id": eventSubscriptionSchemaServiceBusTopicEndpointID(PossibleSystemTopicEventSubscriptionEndpointTypes()),
+
+			"storage_queue_endpoint": eventSubscriptionSchemaStorageQueueEndpoint(PossibleSystemTopicEventSubscriptionEndpointTypes()),
+
+			"webhook_endpoint": eventSubscriptionSchemaWebHookEndpoint(PossibleSystemTopicEventSubscriptionEndpointTypes()),
 
 			"included_event_types": eventSubscriptionSchemaIncludedEventTypes(),
 
@@ -129,6 +113,26 @@ func resourceEventGridSystemTopicEventSubscription() *pluginsdk.Resource {
 			"labels": eventSubscriptionSchemaLabels(),
 
 			"advanced_filtering_on_arrays_enabled": eventSubscriptionSchemaEnableAdvancedFilteringOnArrays(),
+
+			"config_hash": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"endpoint_type": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"system_topic_type": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"provisioning_state": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -139,8 +143,10 @@ func resourceEventGridSystemTopicEventSubscriptionCreateUpdate(d *pluginsdk.Reso
 	defer cancel()
 
 	name := d.Get("name").(string)
-	resourceGroup := d.Get("resource_group_name").(string)
-	systemTopic := d.Get("system_topic").(string)
+	systemTopic, resourceGroup, err := eventGridSystemTopicEventSubscriptionSystemTopic(d.Get("system_topic").(string), d.Get("resource_group_name").(string), meta.(*clients.Client).Account.SubscriptionId)
+	if err != nil {
+		return err
+	}
 
 	if d.IsNewResource() {
 		existing, err := client.Get(ctx, resourceGroup, systemTopic, name)
@@ -157,7 +163,19 @@ func resourceEventGridSystemTopicEventSubscriptionCreateUpdate(d *pluginsdk.Reso
 
 	destination := expandEventGridEventSubscriptionDestination(d)
 	if destination == nil {
-		return fmt.Errorf("One of the following endpoint types must be specificed to create an EventGrid System Topic Event Subscription: %q", PossibleSystemTopicEventSubscriptionEndpointTypes())
+		return EventSubscriptionEndpointNotSpecifiedError{
+			ResourceType:  "EventGrid System Topic Event Subscription",
+			EndpointTypes: PossibleSystemTopicEventSubscriptionEndpointTypes(),
+		}
+	}
+
+	if d.IsNewResource() || d.HasChanges("delivery_identity", "dead_letter_identity") {
+		systemTopicsClient := meta.(*clients.Client).EventGrid.SystemTopicsClient
+		if err := eventGridSystemTopicEventSubscriptionValidateSystemAssignedIdentity(d, func() (eventgrid.SystemTopic, error) {
+			return systemTopicsClient.Get(ctx, resourceGroup, systemTopic)
+		}); err != nil {
+			return fmt.Errorf("validating EventGrid System Topic Event Subscription %q (System Topic %q): %w", name, systemTopic, err)
+		}
 	}
 
 	filter, err := expandEventGridEventSubscriptionFilter(d)
@@ -180,29 +198,19 @@ func resourceEventGridSystemTopicEventSubscriptionCreateUpdate(d *pluginsdk.Reso
 		ExpirationTimeUtc:   expirationTime,
 	}
 
-	if v, ok := d.GetOk("delivery_identity"); ok {
-		deliveryIdentityRaw := v.([]interface{})
-		deliveryIdentity, err := expandEventGridEventSubscriptionIdentity(deliveryIdentityRaw)
-		if err != nil {
-			return fmt.Errorf("expanding `delivery_identity`: %+v", err)
-		}
-
-		eventSubscriptionProperties.DeliveryWithResourceIdentity = &eventgrid.DeliveryWithResourceIdentity{
-			Identity:    deliveryIdentity,
-			Destination: destination,
-		}
-	} else {
-		eventSubscriptionProperties.Destination = destination
+	eventSubscriptionProperties.Destination, eventSubscriptionProperties.DeliveryWithResourceIdentity, err = expandEventGridEventSubscriptionDeliveryDestination(d, destination)
+	if err != nil {
+		return fmt.Errorf("expanding `delivery_identity`: %w", err)
 	}
 
 	if v, ok := d.GetOk("dead_letter_identity"); ok {
 		if deadLetterDestination == nil {
-			return fmt.Errorf("`dead_letter_identity`: `storage_blob_dead_letter_destination` must be specified")
+			return ErrEventSubscriptionDeadLetterDestinationNotSpecified
 		}
 		deadLetterIdentityRaw := v.([]interface{})
 		deadLetterIdentity, err := expandEventGridEventSubscriptionIdentity(deadLetterIdentityRaw)
 		if err != nil {
-			return fmt.Errorf("expanding `dead_letter_identity`: %+v", err)
+			return fmt.Errorf("expanding `dead_letter_identity`: %w", err)
 		}
 
 		eventSubscriptionProperties.DeadLetterWithResourceIdentity = &eventgrid.DeadLetterWithResourceIdentity{
@@ -217,9 +225,30 @@ func resourceEventGridSystemTopicEventSubscriptionCreateUpdate(d *pluginsdk.Reso
 		EventSubscriptionProperties: &eventSubscriptionProperties,
 	}
 
-	log.Printf("[INFO] preparing arguments for AzureRM EventGrid System Topic Event Subscription creation with Properties: %+v.", eventSubscription)
+	// the payload isn't logged since the delivery properties and webhook url can contain secrets
+	log.Printf("[INFO] preparing arguments for AzureRM EventGrid System Topic Event Subscription %q (System Topic %q) creation.", name, systemTopic)
+
+	var future eventgrid.SystemTopicEventSubscriptionsCreateOrUpdateFuture
+	if d.IsNewResource() {
+		// a previous Event Subscription with the same name may still be being deleted, in which case the
+		// create is rejected with a conflict until the deletion has completed
+		err = pluginsdk.Retry(d.Timeout(pluginsdk.TimeoutCreate), func() *pluginsdk.RetryError {
+			var createErr error
+			future, createErr = client.CreateOrUpdate(ctx, resourceGroup, systemTopic, name, eventSubscription)
+			if createErr != nil {
+				if eventSubscriptionIsBeingDeleted(createErr) {
+					log.Printf("[DEBUG] EventGrid System Topic Event Subscription %q (System Topic %q) is still being deleted - retrying", name, systemTopic)
+					return pluginsdk.RetryableError(createErr)
+				}
+
+				return pluginsdk.NonRetryableError(createErr)
+			}
 
-	future, err := client.CreateOrUpdate(ctx, resourceGroup, systemTopic, name, eventSubscription)
+			return nil
+		})
+	} else {
+		future, err = client.CreateOrUpdate(ctx, resourceGroup, systemTopic, name, eventSubscription)
+	}
 	if err != nil {
 		return fmt.Errorf("creating/updating EventGrid System Topic Event Subscription %q (System Topic %q): %s", name, systemTopic, err)
 	}
@@ -228,7 +257,9 @@ func resourceEventGridSystemTopicEventSubscriptionCreateUpdate(d *pluginsdk.Reso
 		return fmt.Errorf("waiting for EventGrid System Topic Event Subscription %q (System Topic %q) to become available: %s", name, systemTopic, err)
 	}
 
-	read, err := client.Get(ctx, resourceGroup, systemTopic, name)
+	read, err := eventSubscriptionGetAfterCreate(func() (eventgrid.EventSubscription, error) {
+		return client.Get(ctx, resourceGroup, systemTopic, name)
+	})
 	if err != nil {
 		return fmt.Errorf("retrieving EventGrid System Topic Event Subscription %q (System Topic %q): %s", name, systemTopic, err)
 	}
@@ -236,11 +267,52 @@ func resourceEventGridSystemTopicEventSubscriptionCreateUpdate(d *pluginsdk.Reso
 		return fmt.Errorf("Cannot read EventGrid System Topic Event Subscription %s (System Topic %s) ID", name, systemTopic)
 	}
 
+	meta.(*clients.Client).EventGrid.RemoveWebhookFullURLFromCache(*read.ID)
 	d.SetId(*read.ID)
 
+	// the long running operation can complete successfully with the subscription in a failed state, in which case
+	// the ID is kept so that the subscription is tainted rather than orphaned
+	if props := read.EventSubscriptionProperties; props != nil && eventSubscriptionProvisioningStateFailed(props.ProvisioningState) {
+		return fmt.Errorf("EventGrid System Topic Event Subscription %q (System Topic %q) has a Provisioning State of %q", name, systemTopic, string(props.ProvisioningState))
+	}
+
 	return resourceEventGridSystemTopicEventSubscriptionRead(d, meta)
 }
 
+// eventGridSystemTopicEventSubscriptionValidateSystemAssignedIdentity checks that the parent System Topic has a System
+// Assigned identity when one is used for delivery or dead-lettering - since the Event Subscription uses the identity
+// of the System Topic, which would otherwise fail at runtime when delivering events
+func eventGridSystemTopicEventSubscriptionValidateSystemAssignedIdentity(d *pluginsdk.ResourceData, getSystemTopic func() (eventgrid.SystemTopic, error)) error {
+	keys := make([]string, 0)
+	for _, key := range []string{"delivery_identity", "dead_letter_identity"} {
+		if v := d.Get(key).([]interface{}); len(v) > 0 && v[0] != nil {
+			if v[0].(map[string]interface{})["type"].(string) == string(eventgrid.SystemAssigned) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	systemTopic, err := getSystemTopic()
+	if err != nil {
+		return fmt.Errorf("retrieving the System Topic to check its identity: %+v", err)
+	}
+
+	if identity := systemTopic.Identity; identity != nil {
+		if identity.Type == eventgrid.IdentityTypeSystemAssigned || identity.Type == eventgrid.IdentityTypeSystemAssignedUserAssigned {
+			return nil
+		}
+	}
+
+	verb := "uses"
+	if len(keys) > 1 {
+		verb = "use"
+	}
+	return fmt.Errorf("`%s` %s the `SystemAssigned` identity of the System Topic, but the System Topic doesn't have a `SystemAssigned` identity enabled", strings.Join(keys, "` and `"), verb)
+}
+
 func resourceEventGridSystemTopicEventSubscriptionRead(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).EventGrid.SystemTopicEventSubscriptionsClient
 	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
@@ -263,7 +335,14 @@ func resourceEventGridSystemTopicEventSubscriptionRead(d *pluginsdk.ResourceData
 	}
 
 	d.Set("name", resp.Name)
-	d.Set("system_topic", id.SystemTopic)
+	// retain the ID of the System Topic when that's what's been specified
+	systemTopic := id.SystemTopic
+	if v := d.Get("system_topic").(string); strings.Contains(v, "/") {
+		if name, resourceGroup, err := eventGridSystemTopicEventSubscriptionSystemTopic(v, "", ""); err == nil && name == id.SystemTopic && strings.EqualFold(resourceGroup, id.ResourceGroup) {
+			systemTopic = v
+		}
+	}
+	d.Set("system_topic", systemTopic)
 	d.Set("resource_group_name", id.ResourceGroup)
 
 	if props := resp.EventSubscriptionProperties; props != nil {
@@ -272,17 +351,18 @@ func resourceEventGridSystemTopicEventSubscriptionRead(d *pluginsdk.ResourceData
 		}
 
 		d.Set("event_delivery_schema", string(props.EventDeliverySchema))
+		d.Set("provisioning_state", string(props.ProvisioningState))
 
-		destination := props.Destination
-		deliveryIdentityFlattened := make([]interface{}, 0)
-		if deliveryIdentity := props.DeliveryWithResourceIdentity; deliveryIdentity != nil {
-			destination = deliveryIdentity.Destination
-			deliveryIdentityFlattened = flattenEventGridEventSubscriptionIdentity(deliveryIdentity.Identity)
-		}
+		destination, deliveryIdentityFlattened := flattenEventGridEventSubscriptionDeliveryDestination(props)
 		if err := d.Set("delivery_identity", deliveryIdentityFlattened); err != nil {
 			return fmt.Errorf("setting `delivery_identity` for EventGrid System Topic Event Subscription %q (System Topic  %q): %s", id.Name, id.SystemTopic, err)
 		}
 
+		if err := resetEventGridEventSubscriptionEndpoints(d, destination, PossibleSystemTopicEventSubscriptionEndpointTypes()); err != nil {
+			return fmt.Errorf("resetting the endpoints for EventGrid System Topic Event Subscription %q (System Topic  %q): %+v", id.Name, id.SystemTopic, err)
+		}
+		d.Set("endpoint_type", flattenEventGridEventSubscriptionEndpointType(destination))
+
 		if azureFunctionEndpoint, ok := destination.AsAzureFunctionEventSubscriptionDestination(); ok {
 			if err := d.Set("azure_function_endpoint", flattenEventGridEventSubscriptionAzureFunctionEndpoint(azureFunctionEndpoint)); err != nil {
 				return fmt.Errorf("setting `%q` for EventGrid System Topic Event Subscription %q (System Topic %q): %s", "azure_function_endpoint", id.Name, id.SystemTopic, err)
@@ -314,11 +394,15 @@ func resourceEventGridSystemTopicEventSubscriptionRead(d *pluginsdk.ResourceData
 			}
 		}
 		if v, ok := destination.AsWebHookEventSubscriptionDestination(); ok {
-			fullURL, err := client.GetFullURL(ctx, id.ResourceGroup, id.SystemTopic, id.Name)
+			fullURL, err := eventSubscriptionWebhookFullURL(v, func() (eventgrid.EventSubscriptionFullURL, error) {
+				return meta.(*clients.Client).EventGrid.WebhookFullURL(d.Id(), func() (eventgrid.EventSubscriptionFullURL, error) {
+					return client.GetFullURL(ctx, id.ResourceGroup, id.SystemTopic, id.Name)
+				})
+			})
 			if err != nil {
-				return fmt.Errorf("making Read request on EventGrid System Topic Event Subscription full URL '%s': %+v", id.Name, err)
+				return fmt.Errorf("retrieving the full url of the `webhook_endpoint` for EventGrid System Topic Event Subscription %q (System Topic %q): %+v", id.Name, id.SystemTopic, err)
 			}
-			if err := d.Set("webhook_endpoint", flattenEventGridEventSubscriptionWebhookEndpoint(v, &fullURL)); err != nil {
+			if err := d.Set("webhook_endpoint", flattenEventGridEventSubscriptionWebhookEndpoint(v, fullURL, d.Get("webhook_endpoint.0.url").(string))); err != nil {
 				return fmt.Errorf("setting `%q` for EventGrid System Topic Event Subscription %q (System Topic %q): %s", "webhook_endpoint", id.Name, id.SystemTopic, err)
 			}
 		}
@@ -343,11 +427,15 @@ func resourceEventGridSystemTopicEventSubscriptionRead(d *pluginsdk.ResourceData
 
 		if filter := props.Filter; filter != nil {
 			d.Set("included_event_types", filter.IncludedEventTypes)
-			d.Set("advanced_filtering_on_arrays_enabled", filter.EnableAdvancedFilteringOnArrays)
-			if err := d.Set("subject_filter", flattenEventGridEventSubscriptionSubjectFilter(filter)); err != nil {
+			d.Set("advanced_filtering_on_arrays_enabled", flattenEventGridEventSubscriptionEnableAdvancedFilteringOnArrays(filter))
+			if err := d.Set("subject_filter", flattenEventGridEventSubscriptionSubjectFilter(d, filter)); err != nil {
 				return fmt.Errorf("setting `subject_filter` for EventGrid System Topic Event Subscription %q (System Topic %q): %s", id.Name, id.SystemTopic, err)
 			}
-			if err := d.Set("advanced_filter", flattenEventGridEventSubscriptionAdvancedFilter(filter)); err != nil {
+			advancedFilter, err := flattenEventGridEventSubscriptionAdvancedFilter(d, filter)
+			if err != nil {
+				return fmt.Errorf("flattening `advanced_filter` for EventGrid System Topic Event Subscription %q (System Topic %q): %+v", id.Name, id.SystemTopic, err)
+			}
+			if err := d.Set("advanced_filter", advancedFilter); err != nil {
 				return fmt.Errorf("setting `advanced_filter` for EventGrid System Topic Event Subscription %q (System Topic %q): %s", id.Name, id.SystemTopic, err)
 			}
 		}
@@ -360,17 +448,40 @@ func resourceEventGridSystemTopicEventSubscriptionRead(d *pluginsdk.ResourceData
 			}
 		}
 
-		if retryPolicy := props.RetryPolicy; retryPolicy != nil {
-			if err := d.Set("retry_policy", flattenEventGridEventSubscriptionRetryPolicy(retryPolicy)); err != nil {
-				return fmt.Errorf("setting `retry_policy` for EventGrid System Topic Event Subscription %q (System Topic %q): %s", id.Name, id.SystemTopic, err)
-			}
+		// this is set even when the API omits the retry policy, using the service defaults, so that reads are consistent
+		if err := d.Set("retry_policy", flattenEventGridEventSubscriptionRetryPolicy(props.RetryPolicy)); err != nil {
+			return fmt.Errorf("setting `retry_policy` for EventGrid System Topic Event Subscription %q (System Topic %q): %s", id.Name, id.SystemTopic, err)
 		}
 
-		if err := d.Set("labels", props.Labels); err != nil {
+		if err := d.Set("labels", flattenEventGridEventSubscriptionLabels(d, props.Labels)); err != nil {
 			return fmt.Errorf("setting `labels` for EventGrid System Topic Event Subscription %q (System Topic %q): %s", id.Name, id.SystemTopic, err)
 		}
 	}
 
+	configHash, err := eventSubscriptionConfigHash(d, PossibleSystemTopicEventSubscriptionEndpointTypes())
+	if err != nil {
+		return fmt.Errorf("hashing the configuration of EventGrid System Topic Event Subscription %q (System Topic %q): %+v", id.Name, id.SystemTopic, err)
+	}
+	d.Set("config_hash", configHash)
+
+	// the topic type of a System Topic can't change and neither can the System Topic a subscription
+	// belongs to, so once this is known there's no need to look it up again on every refresh
+	if d.Get("system_topic_type").(string) == "" {
+		systemTopicsClient := meta.(*clients.Client).EventGrid.SystemTopicsClient
+		systemTopic, err := systemTopicsClient.Get(ctx, id.ResourceGroup, id.SystemTopic)
+		if err != nil {
+			// this is informational only, so it's left unset (and looked up again on the next refresh) rather than
+			// failing the read when the caller can't retrieve the System Topic
+			log.Printf("[WARN] unable to retrieve EventGrid System Topic %q (Resource Group %q) to determine `system_topic_type`: %+v", id.SystemTopic, id.ResourceGroup, err)
+		} else {
+			systemTopicType := ""
+			if props := systemTopic.SystemTopicProperties; props != nil && props.TopicType != nil {
+				systemTopicType = *props.TopicType
+			}
+			d.Set("system_topic_type", systemTopicType)
+		}
+	}
+
 	return nil
 }
 
@@ -384,13 +495,117 @@ func resourceEventGridSystemTopicEventSubscriptionDelete(d *pluginsdk.ResourceDa
 		return err
 	}
 
+	// when the System Topic has already been deleted (taking its Event Subscriptions with it) this returns a 404
 	future, err := client.Delete(ctx, id.ResourceGroup, id.SystemTopic, id.Name)
 	if err != nil {
-		return fmt.Errorf("deleting %s: %+v", *id, err)
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("deleting %s: %+v", *id, err)
+		}
+
+		log.Printf("[DEBUG] EventGrid System Topic Event Subscription %q (System Topic %q) was not found - assuming it has been deleted", id.Name, id.SystemTopic)
+	} else if features.EventGridSkipWaitingForDeletion() {
+		log.Printf("[DEBUG] Not waiting for the deletion of %s to complete as `ARM_EVENTGRID_SKIP_DELETION_WAIT` is set", *id)
+	} else if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("waiting for the deletion of %s: %+v", *id, err)
+		}
 	}
 
-	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
-		return fmt.Errorf("waiting for the deletion of %s: %+v", *id, err)
+	meta.(*clients.Client).EventGrid.RemoveWebhookFullURLFromCache(d.Id())
+
+	return nil
+}
+
+func importEventGridSystemTopicEventSubscription(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
+	client := meta.(*clients.Client).EventGrid.SystemTopicEventSubscriptionsClient
+
+	id, err := parse.SystemTopicEventSubscriptionID(d.Id())
+	if err != nil {
+		return []*pluginsdk.ResourceData{}, err
+	}
+
+	// the Read removes the resource from the state when it's not found, so we check it exists here
+	// to avoid an import which silently results in an empty state (e.g. when the System Topic was deleted)
+	resp, err := client.Get(ctx, id.ResourceGroup, id.SystemTopic, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return []*pluginsdk.ResourceData{}, fmt.Errorf("EventGrid System Topic Event Subscription %q (System Topic %q / Resource Group %q) was not found, it (or its System Topic) may have been deleted and so cannot be imported", id.Name, id.SystemTopic, id.ResourceGroup)
+		}
+
+		return []*pluginsdk.ResourceData{}, fmt.Errorf("retrieving EventGrid System Topic Event Subscription %q (System Topic %q / Resource Group %q): %+v", id.Name, id.SystemTopic, id.ResourceGroup, err)
+	}
+
+	return []*pluginsdk.ResourceData{d}, nil
+}
+
+// eventGridSystemTopicEventSubscriptionSystemTopic returns the name and resource group of the System Topic, which is
+// either specified as a name (in which case `resource_group_name` is required) or as the ID of the System Topic
+func eventGridSystemTopicEventSubscriptionSystemTopic(systemTopic, resourceGroup, subscriptionId string) (string, string, error) {
+	if !strings.Contains(systemTopic, "/") {
+		if resourceGroup == "" {
+			return "", "", fmt.Errorf("`resource_group_name` must be specified when `system_topic` is the name of the System Topic rather than its ID")
+		}
+
+		return systemTopic, resourceGroup, nil
+	}
+
+	id, err := parse.SystemTopicID(systemTopic)
+	if err != nil {
+		return "", "", fmt.Errorf("parsing `system_topic`: %+v", err)
+	}
+
+	if subscriptionId != "" && !strings.EqualFold(id.SubscriptionId, subscriptionId) {
+		return "", "", fmt.Errorf("the System Topic %q must be within the Subscription %q which the provider is configured for", systemTopic, subscriptionId)
+	}
+
+	if resourceGroup != "" && !strings.EqualFold(id.ResourceGroup, resourceGroup) {
+		return "", "", fmt.Errorf("`resource_group_name` (%q) must match the Resource Group of the `system_topic` (%q) when both are specified", resourceGroup, id.ResourceGroup)
+	}
+
+	return id.Name, id.ResourceGroup, nil
+}
+
+// eventGridSystemTopicEventSubscriptionSuppressSystemTopicDiff suppresses the diff between the name of a System Topic
+// and its ID, in which case any change to the Resource Group shows up as a diff on `resource_group_name` instead
+func eventGridSystemTopicEventSubscriptionSuppressSystemTopicDiff(_, old, new string, _ *pluginsdk.ResourceData) bool {
+	if strings.Contains(old, "/") == strings.Contains(new, "/") {
+		return false
+	}
+
+	name, systemTopicId := old, new
+	if strings.Contains(old, "/") {
+		name, systemTopicId = new, old
+	}
+
+	id, err := parse.SystemTopicID(systemTopicId)
+	if err != nil {
+		return false
+	}
+
+	return id.Name == name
+}
+
+// eventGridSystemTopicEventSubscriptionCustomizeDiffSystemTopic checks that the Resource Group is known for the System
+// Topic and, when the ID of the System Topic is specified, plans `resource_group_name` from it
+func eventGridSystemTopicEventSubscriptionCustomizeDiffSystemTopic(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	resourceGroupConfigured := !eventSubscriptionConfigValueIsNull(d, "resource_group_name")
+	if !d.NewValueKnown("system_topic") || (resourceGroupConfigured && !d.NewValueKnown("resource_group_name")) {
+		return nil
+	}
+
+	resourceGroup := d.Get("resource_group_name").(string)
+	if !resourceGroupConfigured {
+		// any value in the state was computed from a previous `system_topic`, so isn't relevant here
+		resourceGroup = ""
+	}
+
+	_, systemTopicResourceGroup, err := eventGridSystemTopicEventSubscriptionSystemTopic(d.Get("system_topic").(string), resourceGroup, "")
+	if err != nil {
+		return err
+	}
+
+	if !resourceGroupConfigured && d.Get("resource_group_name").(string) != systemTopicResourceGroup {
+		return d.SetNew("resource_group_name", systemTopicResourceGroup)
 	}
 
 	return nil
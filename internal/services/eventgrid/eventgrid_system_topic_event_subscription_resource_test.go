@@ -3,6 +3,7 @@ package eventgrid_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -26,6 +27,9 @@ func TestAccEventGridSystemTopicEventSubscription_basic(t *testing.T) {
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
 				check.That(data.ResourceName).Key("event_delivery_schema").HasValue("EventGridSchema"),
+				check.That(data.ResourceName).Key("system_topic_type").HasValue("Microsoft.Resources.ResourceGroups"),
+				check.That(data.ResourceName).Key("endpoint_type").HasValue("StorageQueue"),
+				check.That(data.ResourceName).Key("provisioning_state").HasValue("Succeeded"),
 			),
 		},
 		data.ImportStep(),
@@ -50,6 +54,19 @@ func TestAccEventGridSystemTopicEventSubscription_requiresImport(t *testing.T) {
 	})
 }
 
+func TestAccEventGridSystemTopicEventSubscription_noEndpoint(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_eventgrid_system_topic_event_subscription", "test")
+	r := EventGridSystemTopicEventSubscriptionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.noEndpoint(data),
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile("one of `.+` must be specified"),
+		},
+	})
+}
+
 func TestAccEventGridSystemTopicEventSubscription_eventHubID(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_eventgrid_system_topic_event_subscription", "test")
 	r := EventGridSystemTopicEventSubscriptionResource{}
@@ -241,6 +258,7 @@ func TestAccEventGridSystemTopicEventSubscription_userIdentity(t *testing.T) {
 				check.That(data.ResourceName).Key("delivery_identity.0.type").HasValue("UserAssigned"),
 				check.That(data.ResourceName).Key("dead_letter_identity.#").HasValue("1"),
 				check.That(data.ResourceName).Key("dead_letter_identity.0.type").HasValue("UserAssigned"),
+				check.That(data.ResourceName).Key("dead_letter_identity.0.user_assigned_identity").Exists(),
 			),
 		},
 		data.ImportStep(),
@@ -261,9 +279,86 @@ func TestAccEventGridSystemTopicEventSubscription_userIdentity(t *testing.T) {
 				check.That(data.ResourceName).Key("delivery_identity.0.type").HasValue("UserAssigned"),
 				check.That(data.ResourceName).Key("dead_letter_identity.#").HasValue("1"),
 				check.That(data.ResourceName).Key("dead_letter_identity.0.type").HasValue("UserAssigned"),
+				check.That(data.ResourceName).Key("dead_letter_identity.0.user_assigned_identity").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccEventGridSystemTopicEventSubscription_expirationRelativeToNow(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_eventgrid_system_topic_event_subscription", "test")
+	r := EventGridSystemTopicEventSubscriptionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.expirationRelativeToNow(data, "720h"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("expiration_time_utc").Exists(),
+			),
+		},
+		data.ImportStep("expiration_relative_to_now"),
+		{
+			// the expiration time is within the tolerance window, so shouldn't be re-resolved
+			Config:   r.expirationRelativeToNow(data, "720h"),
+			PlanOnly: true,
+		},
+		{
+			Config: r.expirationRelativeToNow(data, "24h"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("expiration_time_utc").Exists(),
+			),
+		},
+		data.ImportStep("expiration_relative_to_now"),
+	})
+}
+
+func TestAccEventGridSystemTopicEventSubscription_systemTopicID(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_eventgrid_system_topic_event_subscription", "test")
+	r := EventGridSystemTopicEventSubscriptionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.systemTopicReference(data, "name"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
 		data.ImportStep(),
+		{
+			// switching to the ID of the same System Topic should neither replace the Event Subscription nor leave a diff
+			Config: r.systemTopicReference(data, "id"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		// the System Topic is imported by name
+		data.ImportStep("system_topic"),
+	})
+}
+
+func TestAccEventGridSystemTopicEventSubscription_systemTopicSourceUpdated(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_eventgrid_system_topic_event_subscription", "test")
+	r := EventGridSystemTopicEventSubscriptionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.systemTopicSource(data, "test"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("system_topic"),
+		{
+			// re-pointing the source replaces the System Topic, which should also replace the Event Subscription
+			Config: r.systemTopicSource(data, "source"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("system_topic"),
 	})
 }
 
@@ -281,6 +376,20 @@ func (EventGridSystemTopicEventSubscriptionResource) Exists(ctx context.Context,
 	return utils.Bool(resp.EventSubscriptionProperties != nil), nil
 }
 
+func (EventGridSystemTopicEventSubscriptionResource) noEndpoint(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_eventgrid_system_topic_event_subscription" "test" {
+  name                = "acctesteg-%[1]d"
+  system_topic        = "acctesteg-%[1]d"
+  resource_group_name = "acctestRG-eg-%[1]d"
+}
+`, data.RandomInteger)
+}
+
 func (EventGridSystemTopicEventSubscriptionResource) basic(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -1053,3 +1162,143 @@ resource "azurerm_eventgrid_system_topic_event_subscription" "test" {
 }
 `, data.RandomInteger, data.Locations.Primary, data.RandomString)
 }
+
+func (EventGridSystemTopicEventSubscriptionResource) expirationRelativeToNow(data acceptance.TestData, relative string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-eg-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_queue" "test" {
+  name                 = "mysamplequeue-%[1]d"
+  storage_account_name = azurerm_storage_account.test.name
+}
+
+resource "azurerm_eventgrid_system_topic" "test" {
+  name                   = "acctesteg-%[1]d"
+  location               = "Global"
+  resource_group_name    = azurerm_resource_group.test.name
+  source_arm_resource_id = azurerm_resource_group.test.id
+  topic_type             = "Microsoft.Resources.ResourceGroups"
+}
+
+resource "azurerm_eventgrid_system_topic_event_subscription" "test" {
+  name                       = "acctesteg-%[1]d"
+  system_topic               = azurerm_eventgrid_system_topic.test.name
+  resource_group_name        = azurerm_resource_group.test.name
+  expiration_relative_to_now = "%[4]s"
+
+  storage_queue_endpoint {
+    storage_account_id = azurerm_storage_account.test.id
+    queue_name         = azurerm_storage_queue.test.name
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, relative)
+}
+
+func (EventGridSystemTopicEventSubscriptionResource) systemTopicReference(data acceptance.TestData, attribute string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-eg-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_queue" "test" {
+  name                 = "mysamplequeue-%[1]d"
+  storage_account_name = azurerm_storage_account.test.name
+}
+
+resource "azurerm_eventgrid_system_topic" "test" {
+  name                   = "acctesteg-%[1]d"
+  location               = "Global"
+  resource_group_name    = azurerm_resource_group.test.name
+  source_arm_resource_id = azurerm_resource_group.test.id
+  topic_type             = "Microsoft.Resources.ResourceGroups"
+}
+
+resource "azurerm_eventgrid_system_topic_event_subscription" "test" {
+  name                = "acctesteg-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  system_topic        = azurerm_eventgrid_system_topic.test.%[4]s
+
+  storage_queue_endpoint {
+    storage_account_id = azurerm_storage_account.test.id
+    queue_name         = azurerm_storage_queue.test.name
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, attribute)
+}
+
+func (EventGridSystemTopicEventSubscriptionResource) systemTopicSource(data acceptance.TestData, source string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-eg-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_resource_group" "source" {
+  name     = "acctestRG-eg-source-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_queue" "test" {
+  name                 = "mysamplequeue-%[1]d"
+  storage_account_name = azurerm_storage_account.test.name
+}
+
+resource "azurerm_eventgrid_system_topic" "test" {
+  name                   = "acctesteg-%[1]d"
+  location               = "Global"
+  resource_group_name    = azurerm_resource_group.test.name
+  source_arm_resource_id = azurerm_resource_group.%[4]s.id
+  topic_type             = "Microsoft.Resources.ResourceGroups"
+}
+
+resource "azurerm_eventgrid_system_topic_event_subscription" "test" {
+  name         = "acctesteg-%[1]d"
+  system_topic = azurerm_eventgrid_system_topic.test.id
+
+  storage_queue_endpoint {
+    storage_account_id = azurerm_storage_account.test.id
+    queue_name         = azurerm_storage_queue.test.name
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, source)
+}
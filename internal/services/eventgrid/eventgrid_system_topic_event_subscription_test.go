@@ -0,0 +1,404 @@
+package eventgrid
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/eventgrid/mgmt/2020-10-15-preview/eventgrid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestEventGridSystemTopicEventSubscriptionSystemTopic(t *testing.T) {
+	systemTopicId := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.EventGrid/systemTopics/systemTopic1"
+
+	testData := []struct {
+		name                  string
+		systemTopic           string
+		resourceGroup         string
+		subscriptionId        string
+		expectedName          string
+		expectedResourceGroup string
+		expectedError         bool
+	}{
+		{
+			name:                  "name with a resource group",
+			systemTopic:           "systemTopic1",
+			resourceGroup:         "resGroup1",
+			expectedName:          "systemTopic1",
+			expectedResourceGroup: "resGroup1",
+		},
+		{
+			name:          "name without a resource group",
+			systemTopic:   "systemTopic1",
+			expectedError: true,
+		},
+		{
+			name:                  "id without a resource group",
+			systemTopic:           systemTopicId,
+			subscriptionId:        "12345678-1234-9876-4563-123456789012",
+			expectedName:          "systemTopic1",
+			expectedResourceGroup: "resGroup1",
+		},
+		{
+			name:                  "id with the same resource group in a different case",
+			systemTopic:           systemTopicId,
+			resourceGroup:         "RESGROUP1",
+			expectedName:          "systemTopic1",
+			expectedResourceGroup: "resGroup1",
+		},
+		{
+			name:          "id with a different resource group",
+			systemTopic:   systemTopicId,
+			resourceGroup: "resGroup2",
+			expectedError: true,
+		},
+		{
+			name:           "id in a different subscription",
+			systemTopic:    systemTopicId,
+			subscriptionId: "00000000-0000-0000-0000-000000000000",
+			expectedError:  true,
+		},
+		{
+			name:          "id of a different resource",
+			systemTopic:   "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.EventGrid/topics/topic1",
+			expectedError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		name, resourceGroup, err := eventGridSystemTopicEventSubscriptionSystemTopic(v.systemTopic, v.resourceGroup, v.subscriptionId)
+		if v.expectedError {
+			if err == nil {
+				t.Fatalf("Expected an error but didn't get one")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Expected no error but got: %+v", err)
+		}
+		if name != v.expectedName || resourceGroup != v.expectedResourceGroup {
+			t.Fatalf("Expected %q / %q but got %q / %q", v.expectedName, v.expectedResourceGroup, name, resourceGroup)
+		}
+	}
+}
+
+func TestEventGridSystemTopicEventSubscriptionSuppressSystemTopicDiff(t *testing.T) {
+	testData := []struct {
+		old      string
+		new      string
+		suppress bool
+	}{
+		{
+			old:      "systemTopic1",
+			new:      "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.EventGrid/systemTopics/systemTopic1",
+			suppress: true,
+		},
+		{
+			old:      "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.EventGrid/systemTopics/systemTopic1",
+			new:      "systemTopic1",
+			suppress: true,
+		},
+		{
+			old:      "systemTopic1",
+			new:      "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.EventGrid/systemTopics/systemTopic2",
+			suppress: false,
+		},
+		{
+			old:      "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.EventGrid/systemTopics/systemTopic1",
+			new:      "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup2/providers/Microsoft.EventGrid/systemTopics/systemTopic1",
+			suppress: false,
+		},
+		{
+			old:      "",
+			new:      "systemTopic1",
+			suppress: false,
+		},
+	}
+
+	for _, v := range testData {
+		if actual := eventGridSystemTopicEventSubscriptionSuppressSystemTopicDiff("system_topic", v.old, v.new, nil); actual != v.suppress {
+			t.Fatalf("Expected the diff from %q to %q to be suppressed %t but got %t", v.old, v.new, v.suppress, actual)
+		}
+	}
+}
+
+func TestEventGridSystemTopicEventSubscriptionSystemTopicIdDiff(t *testing.T) {
+	raw := map[string]interface{}{
+		"name":         "acctest",
+		"system_topic": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.EventGrid/systemTopics/systemTopic1",
+		"webhook_endpoint": []interface{}{
+			map[string]interface{}{
+				"url": "https://example.com/api/events",
+			},
+		},
+	}
+
+	// the Resource Group is taken from the ID of the System Topic
+	if _, err := resourceEventGridSystemTopicEventSubscription().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil); err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	raw["resource_group_name"] = "resGroup2"
+	_, err := resourceEventGridSystemTopicEventSubscription().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil)
+	if err == nil || !strings.Contains(err.Error(), "must match the Resource Group of the `system_topic`") {
+		t.Fatalf("Expected the plan to fail as the Resource Groups differ but got: %v", err)
+	}
+}
+
+func TestEventGridSystemTopicEventSubscriptionSystemAssignedIdentityRequiresSystemTopicIdentity(t *testing.T) {
+	storageQueueEndpoint := []interface{}{
+		map[string]interface{}{
+			"storage_account_id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account1",
+			"queue_name":         "queue1",
+		},
+	}
+	storageBlobDeadLetterDestination := []interface{}{
+		map[string]interface{}{
+			"storage_account_id":          "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account1",
+			"storage_blob_container_name": "container1",
+		},
+	}
+	identity := func(identityType string) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"type": identityType,
+			},
+		}
+	}
+
+	testData := []struct {
+		name                string
+		raw                 map[string]interface{}
+		systemTopicIdentity string
+		expectedError       string
+		expectLookup        bool
+	}{
+		{
+			name: "no identity",
+			raw: map[string]interface{}{
+				"storage_queue_endpoint": storageQueueEndpoint,
+			},
+		},
+		{
+			name: "dead letter identity with a system topic without an identity",
+			raw: map[string]interface{}{
+				"storage_queue_endpoint":               storageQueueEndpoint,
+				"storage_blob_dead_letter_destination": storageBlobDeadLetterDestination,
+				"dead_letter_identity":                 identity("SystemAssigned"),
+			},
+			expectedError: "`dead_letter_identity` uses the `SystemAssigned` identity of the System Topic, but the System Topic doesn't have a `SystemAssigned` identity enabled",
+			expectLookup:  true,
+		},
+		{
+			name: "delivery and dead letter identities with a system topic with a user assigned identity",
+			raw: map[string]interface{}{
+				"storage_queue_endpoint":               storageQueueEndpoint,
+				"storage_blob_dead_letter_destination": storageBlobDeadLetterDestination,
+				"delivery_identity":                    identity("SystemAssigned"),
+				"dead_letter_identity":                 identity("SystemAssigned"),
+			},
+			systemTopicIdentity: "UserAssigned",
+			expectedError:       "`delivery_identity` and `dead_letter_identity` use the `SystemAssigned` identity of the System Topic",
+			expectLookup:        true,
+		},
+		{
+			name: "dead letter identity with a system topic with a system assigned identity",
+			raw: map[string]interface{}{
+				"storage_queue_endpoint":               storageQueueEndpoint,
+				"storage_blob_dead_letter_destination": storageBlobDeadLetterDestination,
+				"dead_letter_identity":                 identity("SystemAssigned"),
+			},
+			systemTopicIdentity: "SystemAssigned, UserAssigned",
+			expectLookup:        true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		v.raw["name"] = "acctest"
+		v.raw["system_topic"] = "systemTopic1"
+		v.raw["resource_group_name"] = "resGroup1"
+		d := schema.TestResourceDataRaw(t, resourceEventGridSystemTopicEventSubscription().Schema, v.raw)
+
+		looked := false
+		err := eventGridSystemTopicEventSubscriptionValidateSystemAssignedIdentity(d, func() (eventgrid.SystemTopic, error) {
+			looked = true
+			systemTopic := eventgrid.SystemTopic{}
+			if v.systemTopicIdentity != "" {
+				systemTopic.Identity = &eventgrid.IdentityInfo{
+					Type: eventgrid.IdentityType(v.systemTopicIdentity),
+				}
+			}
+			return systemTopic, nil
+		})
+		if looked != v.expectLookup {
+			t.Fatalf("Expected the System Topic to be looked up %t but got %t", v.expectLookup, looked)
+		}
+		if v.expectedError == "" {
+			if err != nil {
+				t.Fatalf("Expected no error but got: %+v", err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), v.expectedError) {
+			t.Fatalf("Expected an error containing %q but got: %v", v.expectedError, err)
+		}
+	}
+
+	// the System Topic is looked up during the create, before the Event Subscription is created
+	requests := make([]string, 0)
+	meta := newTestEventGridClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		// nolint: errcheck
+		w.Write([]byte(`{
+  "id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.EventGrid/systemTopics/systemTopic1",
+  "name": "systemTopic1",
+  "location": "global"
+}`))
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceEventGridSystemTopicEventSubscription().Schema, map[string]interface{}{
+		"name":                                 "acctest",
+		"system_topic":                         "systemTopic1",
+		"resource_group_name":                  "resGroup1",
+		"storage_queue_endpoint":               storageQueueEndpoint,
+		"storage_blob_dead_letter_destination": storageBlobDeadLetterDestination,
+		"dead_letter_identity":                 identity("SystemAssigned"),
+	})
+	err := resourceEventGridSystemTopicEventSubscriptionCreateUpdate(d, meta)
+	if err == nil || !strings.Contains(err.Error(), "doesn't have a `SystemAssigned` identity enabled") {
+		t.Fatalf("Expected the create to fail as the System Topic doesn't have an identity but got: %v", err)
+	}
+	if len(requests) != 1 || requests[0] != "GET /subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.EventGrid/systemTopics/systemTopic1" {
+		t.Fatalf("Expected only the System Topic to be retrieved but got: %+v", requests)
+	}
+}
+
+func TestEventGridSystemTopicEventSubscriptionSystemTopicTypeIsBestEffort(t *testing.T) {
+	id := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.EventGrid/systemTopics/systemTopic1/eventSubscriptions/acctest"
+
+	systemTopicStatusCode := http.StatusForbidden
+	meta := newTestEventGridClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/eventSubscriptions/acctest"):
+			w.WriteHeader(http.StatusOK)
+			// nolint: errcheck
+			w.Write([]byte(fmt.Sprintf(`{
+  "id": %q,
+  "name": "acctest",
+  "properties": {
+    "provisioningState": "Succeeded",
+    "destination": {
+      "endpointType": "StorageQueue",
+      "properties": {
+        "resourceId": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account1",
+        "queueName": "queue1"
+      }
+    },
+    "eventDeliverySchema": "EventGridSchema"
+  }
+}`, id)))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/systemTopics/systemTopic1"):
+			w.WriteHeader(systemTopicStatusCode)
+			if systemTopicStatusCode != http.StatusOK {
+				// nolint: errcheck
+				w.Write([]byte(`{"error": {"code": "AuthorizationFailed", "message": "The client does not have authorization to perform action"}}`))
+				return
+			}
+			// nolint: errcheck
+			w.Write([]byte(`{
+  "id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.EventGrid/systemTopics/systemTopic1",
+  "name": "systemTopic1",
+  "location": "global",
+  "properties": {
+    "topicType": "Microsoft.Storage.StorageAccounts"
+  }
+}`))
+		default:
+			t.Fatalf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceEventGridSystemTopicEventSubscription().Schema, map[string]interface{}{})
+	d.SetId(id)
+
+	// the System Topic can't be retrieved, which shouldn't fail the read
+	if err := resourceEventGridSystemTopicEventSubscriptionRead(d, meta); err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+	if d.Id() == "" {
+		t.Fatalf("Expected the Event Subscription to remain in the state")
+	}
+	if v := d.Get("system_topic_type").(string); v != "" {
+		t.Fatalf("Expected `system_topic_type` to be left unset but got %q", v)
+	}
+
+	// and it's looked up again on the next read
+	systemTopicStatusCode = http.StatusOK
+	if err := resourceEventGridSystemTopicEventSubscriptionRead(d, meta); err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+	if v := d.Get("system_topic_type").(string); v != "Microsoft.Storage.StorageAccounts" {
+		t.Fatalf("Expected `system_topic_type` to be %q but got %q", "Microsoft.Storage.StorageAccounts", v)
+	}
+}
+
+func TestEventGridSystemTopicEventSubscriptionDeleteToleratesMissingSystemTopic(t *testing.T) {
+	testData := []struct {
+		name          string
+		statusCode    int
+		body          string
+		expectedError bool
+	}{
+		{
+			name:       "system topic not found",
+			statusCode: http.StatusNotFound,
+			body:       `{"error": {"code": "ResourceNotFound", "message": "The Resource 'Microsoft.EventGrid/systemTopics/systemTopic1' under resource group 'resGroup1' was not found."}}`,
+		},
+		{
+			name:          "forbidden",
+			statusCode:    http.StatusForbidden,
+			body:          `{"error": {"code": "AuthorizationFailed", "message": "The client does not have authorization to perform this action."}}`,
+			expectedError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Run(v.name, func(t *testing.T) {
+			requests := 0
+			meta := newTestEventGridClient(t, func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.Method != http.MethodDelete {
+					t.Errorf("Expected a DELETE request but got %s %s", r.Method, r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(v.statusCode)
+				w.Write([]byte(v.body)) // nolint: errcheck
+			})
+
+			d := schema.TestResourceDataRaw(t, resourceEventGridSystemTopicEventSubscription().Schema, map[string]interface{}{})
+			d.SetId("/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.EventGrid/systemTopics/systemTopic1/eventSubscriptions/subscription1")
+
+			err := resourceEventGridSystemTopicEventSubscriptionDelete(d, meta)
+			if v.expectedError && err == nil {
+				t.Fatalf("Expected an error but didn't get one")
+			}
+			if !v.expectedError && err != nil {
+				t.Fatalf("Expected no error but got: %+v", err)
+			}
+			if requests == 0 {
+				t.Fatalf("Expected the Event Subscription to be deleted")
+			}
+		})
+	}
+}
@@ -1,15 +1,18 @@
 package eventgrid
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/preview/eventgrid/mgmt/2020-10-15-preview/eventgrid"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/eventgrid/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
@@ -37,6 +40,8 @@ func resourceEventGridSystemTopic() *pluginsdk.Resource {
 			return err
 		}),
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(eventGridSystemTopicCustomizeDiffSource),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:     pluginsdk.TypeString,
@@ -118,14 +123,12 @@ func resourceEventGridSystemTopicCreateUpdate(d *pluginsdk.ResourceData, meta in
 		Tags:                  tags.Expand(t),
 	}
 
-	if v, ok := d.GetOk("identity"); ok {
-		identityRaw := v.([]interface{})
-		identity, err := expandIdentity(identityRaw)
-		if err != nil {
-			return fmt.Errorf("expanding `identity`: %+v", err)
-		}
-		systemTopic.Identity = identity
+	// always send the identity so that removing the `identity` block disables it in-place
+	identity, err := expandIdentity(d.Get("identity").([]interface{}))
+	if err != nil {
+		return fmt.Errorf("expanding `identity`: %+v", err)
 	}
+	systemTopic.Identity = identity
 
 	log.Printf("[INFO] preparing arguments for AzureRM Event Grid System Topic creation with Properties: %+v.", systemTopic)
 
@@ -206,9 +209,57 @@ func resourceEventGridSystemTopicDelete(d *pluginsdk.ResourceData, meta interfac
 		return fmt.Errorf("deleting %s: %+v", *id, err)
 	}
 
-	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+	if features.EventGridSkipWaitingForDeletion() {
+		log.Printf("[DEBUG] Not waiting for the deletion of %s to complete as `ARM_EVENTGRID_SKIP_DELETION_WAIT` is set", *id)
+	} else if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
 		return fmt.Errorf("waiting for the deletion of %s: %+v", *id, err)
 	}
 
 	return nil
 }
+
+// eventGridSystemTopicSourceResourceTypes maps the (lower-cased) Topic Types of a System Topic to the type of resource
+// which must be used as the source of the System Topic
+var eventGridSystemTopicSourceResourceTypes = map[string]string{
+	"microsoft.appconfiguration.configurationstores": "Microsoft.AppConfiguration/configurationStores",
+	"microsoft.communication.communicationservices":  "Microsoft.Communication/communicationServices",
+	"microsoft.containerregistry.registries":         "Microsoft.ContainerRegistry/registries",
+	"microsoft.devices.iothubs":                      "Microsoft.Devices/IotHubs",
+	"microsoft.eventgrid.domains":                    "Microsoft.EventGrid/domains",
+	"microsoft.eventgrid.topics":                     "Microsoft.EventGrid/topics",
+	"microsoft.eventhub.namespaces":                  "Microsoft.EventHub/namespaces",
+	"microsoft.keyvault.vaults":                      "Microsoft.KeyVault/vaults",
+	"microsoft.machinelearningservices.workspaces":   "Microsoft.MachineLearningServices/workspaces",
+	"microsoft.maps.accounts":                        "Microsoft.Maps/accounts",
+	"microsoft.media.mediaservices":                  "Microsoft.Media/mediaservices",
+	"microsoft.policyinsights.policystates":          "Microsoft.Resources/subscriptions",
+	"microsoft.resources.resourcegroups":             "Microsoft.Resources/resourceGroups",
+	"microsoft.resources.subscriptions":              "Microsoft.Resources/subscriptions",
+	"microsoft.servicebus.namespaces":                "Microsoft.ServiceBus/namespaces",
+	"microsoft.signalrservice.signalr":               "Microsoft.SignalRService/SignalR",
+	"microsoft.storage.storageaccounts":              "Microsoft.Storage/storageAccounts",
+	"microsoft.web.serverfarms":                      "Microsoft.Web/serverFarms",
+	"microsoft.web.sites":                            "Microsoft.Web/sites",
+}
+
+// eventGridSystemTopicCustomizeDiffSource checks that the `source_arm_resource_id` is the type of resource which the
+// `topic_type` publishes events for, since otherwise this fails when the System Topic is created. Topic Types which
+// aren't known to the provider are left for the API to validate.
+func eventGridSystemTopicCustomizeDiffSource(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("source_arm_resource_id") || !d.NewValueKnown("topic_type") {
+		return nil
+	}
+
+	topicType := d.Get("topic_type").(string)
+	expected, ok := eventGridSystemTopicSourceResourceTypes[strings.ToLower(topicType)]
+	if !ok {
+		return nil
+	}
+
+	sourceId := d.Get("source_arm_resource_id").(string)
+	if !strings.EqualFold(eventGridResourceTypeFromID(sourceId), expected) {
+		return fmt.Errorf("`source_arm_resource_id` must be the ID of a `%s` when `topic_type` is `%s` but got %q", expected, topicType, sourceId)
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package eventgrid
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestEventGridSystemTopicSourceMatchesTopicType(t *testing.T) {
+	testData := []struct {
+		name          string
+		sourceId      string
+		topicType     string
+		expectedError bool
+	}{
+		{
+			name:      "storage account",
+			sourceId:  "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account1",
+			topicType: "Microsoft.Storage.StorageAccounts",
+		},
+		{
+			name:      "topic type and resource id in a different casing",
+			sourceId:  "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/resGroup1/providers/microsoft.storage/storageaccounts/account1",
+			topicType: "microsoft.storage.storageAccounts",
+		},
+		{
+			name:          "key vault for a storage account topic",
+			sourceId:      "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.KeyVault/vaults/vault1",
+			topicType:     "Microsoft.Storage.StorageAccounts",
+			expectedError: true,
+		},
+		{
+			name:          "storage container for a storage account topic",
+			sourceId:      "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account1/blobServices/default/containers/container1",
+			topicType:     "Microsoft.Storage.StorageAccounts",
+			expectedError: true,
+		},
+		{
+			name:      "resource group",
+			sourceId:  "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1",
+			topicType: "Microsoft.Resources.ResourceGroups",
+		},
+		{
+			name:          "subscription for a resource group topic",
+			sourceId:      "/subscriptions/12345678-1234-9876-4563-123456789012",
+			topicType:     "Microsoft.Resources.ResourceGroups",
+			expectedError: true,
+		},
+		{
+			name:      "subscription for a policy states topic",
+			sourceId:  "/subscriptions/12345678-1234-9876-4563-123456789012",
+			topicType: "Microsoft.PolicyInsights.PolicyStates",
+		},
+		{
+			// topic types which aren't known are left for the API to validate
+			name:      "unknown topic type",
+			sourceId:  "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.KeyVault/vaults/vault1",
+			topicType: "Microsoft.Example.Widgets",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		raw := map[string]interface{}{
+			"name":                   "acctest",
+			"location":               "westeurope",
+			"resource_group_name":    "resGroup1",
+			"source_arm_resource_id": v.sourceId,
+			"topic_type":             v.topicType,
+		}
+
+		_, err := resourceEventGridSystemTopic().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil)
+		if hasError := err != nil; hasError != v.expectedError {
+			t.Fatalf("Expected an error %t but got: %v", v.expectedError, err)
+		}
+		if err != nil && !strings.Contains(err.Error(), "`source_arm_resource_id` must be the ID of a") {
+			t.Fatalf("Expected an error about the `source_arm_resource_id` but got: %+v", err)
+		}
+	}
+}
+
+func TestEventGridSystemTopicSourceChangeReplacesEventSubscription(t *testing.T) {
+	systemTopicId := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.EventGrid/systemTopics/systemTopic1"
+
+	systemTopicState := &terraform.InstanceState{
+		ID: systemTopicId,
+		Attributes: map[string]string{
+			"id":                     systemTopicId,
+			"name":                   "systemTopic1",
+			"resource_group_name":    "resGroup1",
+			"location":               "westeurope",
+			"source_arm_resource_id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account1",
+			"topic_type":             "Microsoft.Storage.StorageAccounts",
+		},
+	}
+	systemTopicRaw := map[string]interface{}{
+		"name":                   "systemTopic1",
+		"resource_group_name":    "resGroup1",
+		"location":               "westeurope",
+		"source_arm_resource_id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account2",
+		"topic_type":             "Microsoft.Storage.StorageAccounts",
+	}
+	diff, err := resourceEventGridSystemTopic().Diff(context.Background(), systemTopicState, terraform.NewResourceConfigRaw(systemTopicRaw), nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+	if diff == nil || !diff.RequiresNew() {
+		t.Fatalf("Expected changing `source_arm_resource_id` to replace the System Topic")
+	}
+
+	// when the System Topic is referenced by its ID, it becomes unknown whilst the System Topic is being replaced
+	// which in turn replaces the Event Subscription, rather than leaving it pointing at the previous System Topic
+	subscriptionState := &terraform.InstanceState{
+		ID: systemTopicId + "/eventSubscriptions/acctest",
+		Attributes: map[string]string{
+			"id":                     systemTopicId + "/eventSubscriptions/acctest",
+			"name":                   "acctest",
+			"system_topic":           systemTopicId,
+			"resource_group_name":    "resGroup1",
+			"event_delivery_schema":  "EventGridSchema",
+			"webhook_endpoint.#":     "1",
+			"webhook_endpoint.0.url": "https://example.com/api/events",
+		},
+	}
+	subscriptionRaw := map[string]interface{}{
+		"name":         "acctest",
+		"system_topic": "74D93920-ED26-11E3-AC10-0800200C9A66",
+		"webhook_endpoint": []interface{}{
+			map[string]interface{}{
+				"url": "https://example.com/api/events",
+			},
+		},
+	}
+	diff, err = resourceEventGridSystemTopicEventSubscription().Diff(context.Background(), subscriptionState, terraform.NewResourceConfigRaw(subscriptionRaw), nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+	if diff == nil || !diff.RequiresNew() {
+		t.Fatalf("Expected the System Topic being replaced to replace the Event Subscription")
+	}
+}
@@ -0,0 +1,125 @@
+package eventgrid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/eventgrid/mgmt/2020-10-15-preview/eventgrid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/eventgrid/client"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+// newTestEventGridClient returns a client whose EventGrid clients send their requests to a fake API served by handler,
+// which is shut down once the test has completed
+func newTestEventGridClient(t *testing.T, handler http.HandlerFunc) *clients.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	subscriptionId := "12345678-1234-9876-4563-123456789012"
+	domainsClient := eventgrid.NewDomainsClientWithBaseURI(server.URL, subscriptionId)
+	eventSubscriptionsClient := eventgrid.NewEventSubscriptionsClientWithBaseURI(server.URL, subscriptionId)
+	systemTopicsClient := eventgrid.NewSystemTopicsClientWithBaseURI(server.URL, subscriptionId)
+	systemTopicEventSubscriptionsClient := eventgrid.NewSystemTopicEventSubscriptionsClientWithBaseURI(server.URL, subscriptionId)
+
+	return &clients.Client{
+		StopContext: context.Background(),
+		Account: &clients.ResourceManagerAccount{
+			SubscriptionId: subscriptionId,
+		},
+		EventGrid: &client.Client{
+			DomainsClient:                       &domainsClient,
+			EventSubscriptionsClient:            &eventSubscriptionsClient,
+			SystemTopicsClient:                  &systemTopicsClient,
+			SystemTopicEventSubscriptionsClient: &systemTopicEventSubscriptionsClient,
+		},
+	}
+}
+
+func TestEventGridTopicInboundIPRulesPreserveConfigOrder(t *testing.T) {
+	rule := func(ipMask string) map[string]interface{} {
+		return map[string]interface{}{
+			"ip_mask": ipMask,
+			"action":  "Allow",
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceEventGridTopic().Schema, map[string]interface{}{
+		"inbound_ip_rule": []interface{}{
+			rule("10.1.0.0/16"),
+			rule("10.0.0.0/16"),
+			rule("203.0.113.10"),
+		},
+	})
+
+	// the API doesn't guarantee the order the rules are returned in
+	rules := []eventgrid.InboundIPRule{
+		{IPMask: utils.String("10.0.0.0/16"), Action: eventgrid.Allow},
+		{IPMask: utils.String("203.0.113.10"), Action: eventgrid.Allow},
+		{IPMask: utils.String("10.2.0.0/16"), Action: eventgrid.Allow},
+		{IPMask: utils.String("10.1.0.0/16"), Action: eventgrid.Allow},
+	}
+
+	actual := orderInboundIPRulesByConfig(d, flattenInboundIPRules(&rules))
+	expected := []string{"10.1.0.0/16", "10.0.0.0/16", "203.0.113.10", "10.2.0.0/16"}
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected %d rules but got %d", len(expected), len(actual))
+	}
+	for i, ipMask := range expected {
+		if v := actual[i].(map[string]interface{})["ip_mask"].(string); v != ipMask {
+			t.Fatalf("Expected rule %d to have the IP Mask %q but got %q", i, ipMask, v)
+		}
+	}
+
+	// when importing there's no config, so the order returned by the API is kept
+	imported := schema.TestResourceDataRaw(t, resourceEventGridTopic().Schema, map[string]interface{}{})
+	actual = orderInboundIPRulesByConfig(imported, flattenInboundIPRules(&rules))
+	for i, r := range rules {
+		if v := actual[i].(map[string]interface{})["ip_mask"].(string); v != *r.IPMask {
+			t.Fatalf("Expected imported rule %d to have the IP Mask %q but got %q", i, *r.IPMask, v)
+		}
+	}
+}
+
+func TestEventGridResourceTypeFromID(t *testing.T) {
+	testData := []struct {
+		id       string
+		expected string
+	}{
+		{
+			id:       "/subscriptions/12345678-1234-9876-4563-123456789012",
+			expected: "Microsoft.Resources/subscriptions",
+		},
+		{
+			id:       "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1",
+			expected: "Microsoft.Resources/resourceGroups",
+		},
+		{
+			id:       "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account1",
+			expected: "Microsoft.Storage/storageAccounts",
+		},
+		{
+			id:       "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.EventGrid/domains/domain1/topics/topic1",
+			expected: "Microsoft.EventGrid/domains/topics",
+		},
+		{
+			// the first segment must be the Subscription
+			id: "/tenants/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1",
+		},
+		{
+			id: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts",
+		},
+		{
+			id: "not-a-resource-id",
+		},
+	}
+
+	for _, v := range testData {
+		if actual := eventGridResourceTypeFromID(v.id); actual != v.expected {
+			t.Fatalf("Expected the type of %q to be %q but got %q", v.id, v.expected, actual)
+		}
+	}
+}
@@ -37,6 +37,11 @@ func dataSourceEventGridTopic() *pluginsdk.Resource {
 				Computed: true,
 			},
 
+			"metric_arm_resource_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"primary_access_key": {
 				Type:      pluginsdk.TypeString,
 				Computed:  true,
@@ -86,6 +91,7 @@ func dataSourceEventGridTopicRead(d *pluginsdk.ResourceData, meta interface{}) e
 
 	if props := resp.TopicProperties; props != nil {
 		d.Set("endpoint", props.Endpoint)
+		d.Set("metric_arm_resource_id", props.MetricResourceID)
 	}
 
 	d.Set("primary_access_key", keys.Key1)
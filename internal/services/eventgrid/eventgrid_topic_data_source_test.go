@@ -20,6 +20,7 @@ func TestAccEventGridTopicDataSource_basic(t *testing.T) {
 			Config: r.basic(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).Key("endpoint").Exists(),
+				check.That(data.ResourceName).Key("metric_arm_resource_id").Exists(),
 				check.That(data.ResourceName).Key("primary_access_key").Exists(),
 				check.That(data.ResourceName).Key("secondary_access_key").Exists(),
 			),
@@ -1,6 +1,7 @@
 package eventgrid
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"regexp"
@@ -10,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/eventgrid/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
@@ -37,6 +39,8 @@ func resourceEventGridTopic() *pluginsdk.Resource {
 			return err
 		}),
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(eventGridTopicCustomizeDiffInputMapping),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:     pluginsdk.TypeString,
@@ -147,6 +151,11 @@ func resourceEventGridTopic() *pluginsdk.Resource {
 				Computed: true,
 			},
 
+			"metric_arm_resource_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"primary_access_key": {
 				Type:      pluginsdk.TypeString,
 				Computed:  true,
@@ -256,6 +265,7 @@ func resourceEventGridTopicRead(d *pluginsdk.ResourceData, meta interface{}) err
 	}
 	if props := resp.TopicProperties; props != nil {
 		d.Set("endpoint", props.Endpoint)
+		d.Set("metric_arm_resource_id", props.MetricResourceID)
 
 		d.Set("input_schema", string(props.InputSchema))
 
@@ -280,7 +290,7 @@ func resourceEventGridTopicRead(d *pluginsdk.ResourceData, meta interface{}) err
 			return fmt.Errorf("setting `public_network_access_enabled` in EventGrid Topic %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
 		}
 
-		inboundIPRules := flattenInboundIPRules(props.InboundIPRules)
+		inboundIPRules := orderInboundIPRulesByConfig(d, flattenInboundIPRules(props.InboundIPRules))
 		if err := d.Set("inbound_ip_rule", inboundIPRules); err != nil {
 			return fmt.Errorf("setting `inbound_ip_rule` in EventGrid Topic %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
 		}
@@ -326,13 +336,40 @@ func resourceEventGridTopicDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		return fmt.Errorf("deleting EventGrid Topic %q: %+v", id.Name, err)
 	}
 
-	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+	if features.EventGridSkipWaitingForDeletion() {
+		log.Printf("[DEBUG] Not waiting for the deletion of EventGrid Topic %q to complete as `ARM_EVENTGRID_SKIP_DELETION_WAIT` is set", id.Name)
+	} else if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
 		return fmt.Errorf("deleting EventGrid Topic %q: %+v", id.Name, err)
 	}
 
 	return nil
 }
 
+// eventGridTopicCustomizeDiffInputMapping checks that an input mapping is specified when (and only when) the
+// Topic uses a custom input schema, since otherwise this fails when the Topic is created
+func eventGridTopicCustomizeDiffInputMapping(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("input_schema") {
+		return nil
+	}
+
+	inputSchema := d.Get("input_schema").(string)
+	if inputSchema == string(eventgrid.InputSchemaCustomEventSchema) {
+		if v := d.Get("input_mapping_fields").([]interface{}); len(v) == 0 {
+			return fmt.Errorf("`input_mapping_fields` must be specified when `input_schema` is `%s`", inputSchema)
+		}
+
+		return nil
+	}
+
+	for _, key := range []string{"input_mapping_fields", "input_mapping_default_values"} {
+		if v := d.Get(key).([]interface{}); len(v) > 0 {
+			return fmt.Errorf("`%s` can only be specified when `input_schema` is `%s`", key, eventgrid.InputSchemaCustomEventSchema)
+		}
+	}
+
+	return nil
+}
+
 func expandAzureRmEventgridTopicInputMapping(d *pluginsdk.ResourceData) *eventgrid.JSONInputSchemaMapping {
 	imf, imfok := d.GetOk("input_mapping_fields")
 
@@ -26,6 +26,7 @@ func TestAccEventGridTopic_basic(t *testing.T) {
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
 				check.That(data.ResourceName).Key("endpoint").Exists(),
+				check.That(data.ResourceName).Key("metric_arm_resource_id").Exists(),
 				check.That(data.ResourceName).Key("primary_access_key").Exists(),
 				check.That(data.ResourceName).Key("secondary_access_key").Exists(),
 			),
@@ -111,6 +112,32 @@ func TestAccEventGridTopic_inboundIPRules(t *testing.T) {
 	})
 }
 
+func TestAccEventGridTopic_inboundIPRulesUpdate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_eventgrid_topic", "test")
+	r := EventGridTopicResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.inboundIPRules(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.inboundIPRulesUpdated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("inbound_ip_rule.#").HasValue("3"),
+				check.That(data.ResourceName).Key("inbound_ip_rule.0.ip_mask").HasValue("10.1.0.0/16"),
+				check.That(data.ResourceName).Key("inbound_ip_rule.1.ip_mask").HasValue("10.0.0.0/16"),
+				check.That(data.ResourceName).Key("inbound_ip_rule.2.ip_mask").HasValue("203.0.113.10"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccEventGridTopic_basicWithSystemManagedIdentity(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_eventgrid_topic", "test")
 	r := EventGridTopicResource{}
@@ -280,6 +307,39 @@ resource "azurerm_eventgrid_topic" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
 }
 
+func (EventGridTopicResource) inboundIPRulesUpdated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_eventgrid_topic" "test" {
+  name                = "acctesteg-%d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+
+  inbound_ip_rule {
+    ip_mask = "10.1.0.0/16"
+    action  = "Allow"
+  }
+
+  inbound_ip_rule {
+    ip_mask = "10.0.0.0/16"
+    action  = "Allow"
+  }
+
+  inbound_ip_rule {
+    ip_mask = "203.0.113.10"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
+}
+
 func (EventGridTopicResource) basicWithSystemManagedIdentity(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -0,0 +1,88 @@
+package eventgrid
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestEventGridTopicInputMappingValidatedDuringPlan(t *testing.T) {
+	inputMappingFields := []interface{}{
+		map[string]interface{}{
+			"topic": "test",
+		},
+	}
+	inputMappingDefaultValues := []interface{}{
+		map[string]interface{}{
+			"subject": "DefaultSubject",
+		},
+	}
+
+	testData := []struct {
+		name          string
+		raw           map[string]interface{}
+		expectedError string
+	}{
+		{
+			name: "custom schema with input mapping fields",
+			raw: map[string]interface{}{
+				"input_schema":                 "CustomEventSchema",
+				"input_mapping_fields":         inputMappingFields,
+				"input_mapping_default_values": inputMappingDefaultValues,
+			},
+		},
+		{
+			name: "custom schema without input mapping fields",
+			raw: map[string]interface{}{
+				"input_schema":                 "CustomEventSchema",
+				"input_mapping_default_values": inputMappingDefaultValues,
+			},
+			expectedError: "`input_mapping_fields` must be specified",
+		},
+		{
+			name: "default schema without an input mapping",
+			raw:  map[string]interface{}{},
+		},
+		{
+			name: "cloud event schema with input mapping fields",
+			raw: map[string]interface{}{
+				"input_schema":         "CloudEventSchemaV1_0",
+				"input_mapping_fields": inputMappingFields,
+			},
+			expectedError: "`input_mapping_fields` can only be specified",
+		},
+		{
+			name: "default schema with input mapping default values",
+			raw: map[string]interface{}{
+				"input_mapping_default_values": inputMappingDefaultValues,
+			},
+			expectedError: "`input_mapping_default_values` can only be specified",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		raw := map[string]interface{}{
+			"name":                "acctest",
+			"location":            "westeurope",
+			"resource_group_name": "resGroup1",
+		}
+		for key, value := range v.raw {
+			raw[key] = value
+		}
+
+		_, err := resourceEventGridTopic().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil)
+		if v.expectedError == "" {
+			if err != nil {
+				t.Fatalf("Expected no error but got: %+v", err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), v.expectedError) {
+			t.Fatalf("Expected an error containing %q but got: %v", v.expectedError, err)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package eventgrid
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func dataSourceEventGridTopicType() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceEventGridTopicTypeRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"provider_name": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"display_name": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"description": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"supported_locations": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"event_types": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceEventGridTopicTypeRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).EventGrid.TopicTypesClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	name := d.Get("name").(string)
+
+	resp, err := client.Get(ctx, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("EventGrid Topic Type %q was not found", name)
+		}
+
+		return fmt.Errorf("retrieving EventGrid Topic Type %q: %+v", name, err)
+	}
+	if resp.ID == nil || *resp.ID == "" {
+		return fmt.Errorf("retrieving EventGrid Topic Type %q: `id` was nil", name)
+	}
+
+	eventTypes, err := client.ListEventTypes(ctx, name)
+	if err != nil {
+		return fmt.Errorf("listing the Event Types for EventGrid Topic Type %q: %+v", name, err)
+	}
+
+	d.SetId(*resp.ID)
+	d.Set("name", name)
+
+	if props := resp.TopicTypeProperties; props != nil {
+		d.Set("provider_name", props.Provider)
+		d.Set("display_name", props.DisplayName)
+		d.Set("description", props.Description)
+		d.Set("supported_locations", utils.FlattenStringSlice(props.SupportedLocations))
+	}
+
+	names := make([]string, 0)
+	if eventTypes.Value != nil {
+		for _, v := range *eventTypes.Value {
+			if v.Name != nil {
+				names = append(names, *v.Name)
+			}
+		}
+	}
+	if err := d.Set("event_types", names); err != nil {
+		return fmt.Errorf("setting `event_types`: %+v", err)
+	}
+
+	return nil
+}
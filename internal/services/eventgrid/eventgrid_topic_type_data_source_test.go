@@ -0,0 +1,40 @@
+package eventgrid_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type EventGridTopicTypeDataSource struct {
+}
+
+func TestAccEventGridTopicTypeDataSource_storageAccounts(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_eventgrid_topic_type", "test")
+	r := EventGridTopicTypeDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.storageAccounts(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("provider_name").HasValue("Microsoft.Storage"),
+				check.That(data.ResourceName).Key("display_name").Exists(),
+				check.That(data.ResourceName).Key("supported_locations.#").Exists(),
+				check.That(data.ResourceName).Key("event_types.#").Exists(),
+			),
+		},
+	})
+}
+
+func (EventGridTopicTypeDataSource) storageAccounts() string {
+	return `
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_eventgrid_topic_type" "test" {
+  name = "Microsoft.Storage.StorageAccounts"
+}
+`
+}
@@ -0,0 +1,148 @@
+package parse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+)
+
+// EventSubscriptionScopeType is the type of scope which an Event Subscription has been created within
+type EventSubscriptionScopeType string
+
+const (
+	// EventSubscriptionScopeTypeSystemTopic is an Event Subscription nested within an EventGrid System Topic
+	EventSubscriptionScopeTypeSystemTopic EventSubscriptionScopeType = "SystemTopic"
+	// EventSubscriptionScopeTypePartnerTopic is an Event Subscription nested within an EventGrid Partner Topic
+	EventSubscriptionScopeTypePartnerTopic EventSubscriptionScopeType = "PartnerTopic"
+	// EventSubscriptionScopeTypeDomainTopic is an Event Subscription scoped to an EventGrid Domain Topic
+	EventSubscriptionScopeTypeDomainTopic EventSubscriptionScopeType = "DomainTopic"
+	// EventSubscriptionScopeTypeResource is an Event Subscription scoped to any other Subscription, Resource Group or Resource
+	EventSubscriptionScopeTypeResource EventSubscriptionScopeType = "Resource"
+)
+
+const eventSubscriptionExtensionSegment = "/providers/Microsoft.EventGrid/eventSubscriptions/"
+
+type EventSubscriptionScopedId struct {
+	ScopeType EventSubscriptionScopeType
+
+	// Scope is the ID of the Subscription, Resource Group or Resource which the Event Subscription is within
+	Scope string
+
+	SubscriptionId string
+	ResourceGroup  string
+
+	// DomainName is only set for Event Subscriptions scoped to an EventGrid Domain Topic
+	DomainName string
+
+	// TopicName is the name of the System Topic, Partner Topic or Domain Topic, when the Event Subscription is within one
+	TopicName string
+
+	Name string
+}
+
+func (id EventSubscriptionScopedId) String() string {
+	segments := []string{
+		fmt.Sprintf("Name %q", id.Name),
+		fmt.Sprintf("Scope %q", id.Scope),
+	}
+	segmentsStr := strings.Join(segments, " / ")
+	return fmt.Sprintf("%s: (%s)", "Event Subscription", segmentsStr)
+}
+
+func (id EventSubscriptionScopedId) ID() string {
+	switch id.ScopeType {
+	case EventSubscriptionScopeTypeSystemTopic, EventSubscriptionScopeTypePartnerTopic:
+		return fmt.Sprintf("%s/eventSubscriptions/%s", id.Scope, id.Name)
+	default:
+		return fmt.Sprintf("%s%s%s", id.Scope, eventSubscriptionExtensionSegment, id.Name)
+	}
+}
+
+// EventSubscriptionScopedID parses the ID of an Event Subscription within any scope - either nested within an
+// EventGrid System Topic or Partner Topic, or as an extension of a Domain Topic or any other Subscription, Resource
+// Group or Resource - into an EventSubscriptionScopedId struct
+func EventSubscriptionScopedID(input string) (*EventSubscriptionScopedId, error) {
+	id, err := azure.ParseAzureResourceID(input)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Unable to parse EventGrid Event Subscription ID %q: %+v", input, err)
+	}
+
+	if segments := strings.Split(input, eventSubscriptionExtensionSegment); len(segments) > 1 {
+		if len(segments) != 2 || segments[0] == "" || segments[1] == "" || strings.Contains(segments[1], "/") {
+			return nil, fmt.Errorf("Expected ID to be in the format `{scope}%s{name}` - got %q", eventSubscriptionExtensionSegment, input)
+		}
+
+		eventSubscription := EventSubscriptionScopedId{
+			ScopeType:      EventSubscriptionScopeTypeResource,
+			Scope:          segments[0],
+			SubscriptionId: id.SubscriptionID,
+			ResourceGroup:  id.ResourceGroup,
+			Name:           segments[1],
+		}
+
+		if domainTopic, err := DomainTopicID(segments[0]); err == nil {
+			eventSubscription.ScopeType = EventSubscriptionScopeTypeDomainTopic
+			eventSubscription.DomainName = domainTopic.DomainName
+			eventSubscription.TopicName = domainTopic.TopicName
+		}
+
+		return &eventSubscription, nil
+	}
+
+	if !strings.EqualFold(id.Provider, "Microsoft.EventGrid") {
+		return nil, fmt.Errorf("Expected ID %q to be an Event Subscription within an EventGrid System Topic or Partner Topic, or in the format `{scope}%s{name}`", input, eventSubscriptionExtensionSegment)
+	}
+
+	eventSubscription := EventSubscriptionScopedId{
+		SubscriptionId: id.SubscriptionID,
+		ResourceGroup:  id.ResourceGroup,
+	}
+
+	topicsSegment := ""
+	for _, v := range []struct {
+		segment   string
+		scopeType EventSubscriptionScopeType
+	}{
+		{
+			segment:   "systemTopics",
+			scopeType: EventSubscriptionScopeTypeSystemTopic,
+		},
+		{
+			segment:   "partnerTopics",
+			scopeType: EventSubscriptionScopeTypePartnerTopic,
+		},
+	} {
+		if _, ok := id.Path[v.segment]; ok {
+			topicsSegment = v.segment
+			eventSubscription.ScopeType = v.scopeType
+			break
+		}
+	}
+	if topicsSegment == "" {
+		return nil, fmt.Errorf("Expected ID %q to be an Event Subscription within an EventGrid System Topic or Partner Topic, or in the format `{scope}%s{name}`", input, eventSubscriptionExtensionSegment)
+	}
+
+	if eventSubscription.SubscriptionId == "" {
+		return nil, fmt.Errorf("ID was missing the 'subscriptions' element")
+	}
+
+	if eventSubscription.ResourceGroup == "" {
+		return nil, fmt.Errorf("ID was missing the 'resourceGroups' element")
+	}
+
+	if eventSubscription.TopicName, err = id.PopSegment(topicsSegment); err != nil {
+		return nil, err
+	}
+	if eventSubscription.Name, err = id.PopSegment("eventSubscriptions"); err != nil {
+		return nil, err
+	}
+
+	if err := id.ValidateNoEmptySegments(input); err != nil {
+		return nil, err
+	}
+
+	eventSubscription.Scope = fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.EventGrid/%s/%s", eventSubscription.SubscriptionId, eventSubscription.ResourceGroup, topicsSegment, eventSubscription.TopicName)
+
+	return &eventSubscription, nil
+}
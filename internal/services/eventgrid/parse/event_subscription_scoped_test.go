@@ -0,0 +1,159 @@
+package parse
+
+import (
+	"testing"
+)
+
+func TestEventSubscriptionScopedID(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    string
+		Expected *EventSubscriptionScopedId
+	}{
+		{
+			Name:     "Empty",
+			Input:    "",
+			Expected: nil,
+		},
+		{
+			Name:     "Missing Event Subscription",
+			Input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.EventGrid/systemTopics/topic1",
+			Expected: nil,
+		},
+		{
+			Name:     "Missing Event Subscription Name",
+			Input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.EventGrid/systemTopics/topic1/eventSubscriptions/",
+			Expected: nil,
+		},
+		{
+			Name:     "Non EventGrid Resource",
+			Input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account1",
+			Expected: nil,
+		},
+		{
+			Name:     "Nested within an EventGrid Topic",
+			Input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.EventGrid/topics/topic1/eventSubscriptions/subscription1",
+			Expected: nil,
+		},
+		{
+			Name:     "System Topic Missing Resource Group",
+			Input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/providers/Microsoft.EventGrid/systemTopics/topic1/eventSubscriptions/subscription1",
+			Expected: nil,
+		},
+		{
+			Name:     "System Topic with Extra Segments",
+			Input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.EventGrid/systemTopics/topic1/eventSubscriptions/subscription1/foo/bar",
+			Expected: nil,
+		},
+		{
+			Name:     "Extension with a Nested Name",
+			Input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.EventGrid/eventSubscriptions/subscription1/foo",
+			Expected: nil,
+		},
+		{
+			Name:  "System Topic",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.EventGrid/systemTopics/topic1/eventSubscriptions/subscription1",
+			Expected: &EventSubscriptionScopedId{
+				ScopeType:      EventSubscriptionScopeTypeSystemTopic,
+				Scope:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.EventGrid/systemTopics/topic1",
+				SubscriptionId: "00000000-0000-0000-0000-000000000000",
+				ResourceGroup:  "resGroup1",
+				TopicName:      "topic1",
+				Name:           "subscription1",
+			},
+		},
+		{
+			Name:  "Partner Topic",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.EventGrid/partnerTopics/topic1/eventSubscriptions/subscription1",
+			Expected: &EventSubscriptionScopedId{
+				ScopeType:      EventSubscriptionScopeTypePartnerTopic,
+				Scope:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.EventGrid/partnerTopics/topic1",
+				SubscriptionId: "00000000-0000-0000-0000-000000000000",
+				ResourceGroup:  "resGroup1",
+				TopicName:      "topic1",
+				Name:           "subscription1",
+			},
+		},
+		{
+			Name:  "Domain Topic",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.EventGrid/domains/domain1/topics/topic1/providers/Microsoft.EventGrid/eventSubscriptions/subscription1",
+			Expected: &EventSubscriptionScopedId{
+				ScopeType:      EventSubscriptionScopeTypeDomainTopic,
+				Scope:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.EventGrid/domains/domain1/topics/topic1",
+				SubscriptionId: "00000000-0000-0000-0000-000000000000",
+				ResourceGroup:  "resGroup1",
+				DomainName:     "domain1",
+				TopicName:      "topic1",
+				Name:           "subscription1",
+			},
+		},
+		{
+			Name:  "Domain",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.EventGrid/domains/domain1/providers/Microsoft.EventGrid/eventSubscriptions/subscription1",
+			Expected: &EventSubscriptionScopedId{
+				ScopeType:      EventSubscriptionScopeTypeResource,
+				Scope:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.EventGrid/domains/domain1",
+				SubscriptionId: "00000000-0000-0000-0000-000000000000",
+				ResourceGroup:  "resGroup1",
+				Name:           "subscription1",
+			},
+		},
+		{
+			Name:  "Storage Account",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account1/providers/Microsoft.EventGrid/eventSubscriptions/subscription1",
+			Expected: &EventSubscriptionScopedId{
+				ScopeType:      EventSubscriptionScopeTypeResource,
+				Scope:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account1",
+				SubscriptionId: "00000000-0000-0000-0000-000000000000",
+				ResourceGroup:  "resGroup1",
+				Name:           "subscription1",
+			},
+		},
+		{
+			Name:  "Resource Group",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1/providers/Microsoft.EventGrid/eventSubscriptions/subscription1",
+			Expected: &EventSubscriptionScopedId{
+				ScopeType:      EventSubscriptionScopeTypeResource,
+				Scope:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resGroup1",
+				SubscriptionId: "00000000-0000-0000-0000-000000000000",
+				ResourceGroup:  "resGroup1",
+				Name:           "subscription1",
+			},
+		},
+		{
+			Name:  "Subscription",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.EventGrid/eventSubscriptions/subscription1",
+			Expected: &EventSubscriptionScopedId{
+				ScopeType:      EventSubscriptionScopeTypeResource,
+				Scope:          "/subscriptions/00000000-0000-0000-0000-000000000000",
+				SubscriptionId: "00000000-0000-0000-0000-000000000000",
+				Name:           "subscription1",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual, err := EventSubscriptionScopedID(v.Input)
+		if err != nil {
+			if v.Expected == nil {
+				continue
+			}
+
+			t.Fatalf("Expected a value but got an error: %s", err)
+		}
+
+		if v.Expected == nil {
+			t.Fatalf("Expected an error but got %+v", *actual)
+		}
+
+		if *actual != *v.Expected {
+			t.Fatalf("Expected %+v but got %+v", *v.Expected, *actual)
+		}
+
+		if actual.ID() != v.Input {
+			t.Fatalf("Expected the ID to round-trip to %q but got %q", v.Input, actual.ID())
+		}
+	}
+}
@@ -21,10 +21,13 @@ func (r Registration) WebsiteCategories() []string {
 // SupportedDataSources returns the supported Data Sources supported by this Service
 func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azurerm_eventgrid_topic":        dataSourceEventGridTopic(),
-		"azurerm_eventgrid_domain":       dataSourceEventGridDomain(),
-		"azurerm_eventgrid_domain_topic": dataSourceEventGridDomainTopic(),
-		"azurerm_eventgrid_system_topic": dataSourceEventGridSystemTopic(),
+		"azurerm_eventgrid_topic":                dataSourceEventGridTopic(),
+		"azurerm_eventgrid_domain":               dataSourceEventGridDomain(),
+		"azurerm_eventgrid_domain_topic":         dataSourceEventGridDomainTopic(),
+		"azurerm_eventgrid_event_subscription":   dataSourceEventGridEventSubscription(),
+		"azurerm_eventgrid_partner_registration": dataSourceEventGridPartnerRegistration(),
+		"azurerm_eventgrid_system_topic":         dataSourceEventGridSystemTopic(),
+		"azurerm_eventgrid_topic_type":           dataSourceEventGridTopicType(),
 	}
 }
 
@@ -34,6 +37,7 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 		"azurerm_eventgrid_domain":                          resourceEventGridDomain(),
 		"azurerm_eventgrid_domain_topic":                    resourceEventGridDomainTopic(),
 		"azurerm_eventgrid_event_subscription":              resourceEventGridEventSubscription(),
+		"azurerm_eventgrid_partner_namespace":               resourceEventGridPartnerNamespace(),
 		"azurerm_eventgrid_topic":                           resourceEventGridTopic(),
 		"azurerm_eventgrid_system_topic":                    resourceEventGridSystemTopic(),
 		"azurerm_eventgrid_system_topic_event_subscription": resourceEventGridSystemTopicEventSubscription(),
@@ -0,0 +1,36 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+)
+
+// AzureFunctionID validates the ID of a Function within a Function App (or one of its Slots), which is what an
+// `azure_function_endpoint` delivers events to - rather than the ID of the Function App itself
+func AzureFunctionID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	id, err := azure.ParseAzureResourceID(v)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("parsing %q: %+v", key, err))
+		return
+	}
+
+	expectedSegments := 2
+	if _, ok := id.Path["slots"]; ok {
+		expectedSegments = 3
+	}
+
+	if !strings.EqualFold(id.Provider, "Microsoft.Web") || id.Path["sites"] == "" || id.Path["functions"] == "" || len(id.Path) != expectedSegments {
+		errors = append(errors, fmt.Errorf("expected %q to be the ID of a Function within a Function App, in the format `/subscriptions/{subscriptionId}/resourceGroups/{resourceGroup}/providers/Microsoft.Web/sites/{functionApp}/functions/{function}`, got %q", key, v))
+		return
+	}
+
+	return
+}
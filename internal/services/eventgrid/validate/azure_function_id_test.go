@@ -0,0 +1,59 @@
+package validate
+
+import "testing"
+
+func TestAzureFunctionID(t *testing.T) {
+	cases := []struct {
+		Value       string
+		ShouldError bool
+	}{
+		{
+			// empty
+			Value:       "",
+			ShouldError: true,
+		},
+		{
+			// function app
+			Value:       "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Web/sites/functionApp1",
+			ShouldError: true,
+		},
+		{
+			// function
+			Value:       "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Web/sites/functionApp1/functions/function1",
+			ShouldError: false,
+		},
+		{
+			// function within a slot
+			Value:       "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Web/sites/functionApp1/slots/staging/functions/function1",
+			ShouldError: false,
+		},
+		{
+			// missing function name
+			Value:       "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Web/sites/functionApp1/functions/",
+			ShouldError: true,
+		},
+		{
+			// another child resource of the function app
+			Value:       "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Web/sites/functionApp1/config/web",
+			ShouldError: true,
+		},
+		{
+			// different resource provider
+			Value:       "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.EventHub/sites/functionApp1/functions/function1",
+			ShouldError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := AzureFunctionID(tc.Value, "function_id")
+
+		hasErrors := len(errors) > 0
+		if hasErrors && !tc.ShouldError {
+			t.Fatalf("Expected no errors but got %q for %q", errors[0], tc.Value)
+		}
+
+		if !hasErrors && tc.ShouldError {
+			t.Fatalf("Expected an error but got none for %q", tc.Value)
+		}
+	}
+}
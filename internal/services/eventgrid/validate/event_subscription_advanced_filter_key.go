@@ -0,0 +1,35 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EventSubscriptionAdvancedFilterKey validates the key of an advanced filter, which is either a property of the
+// event (e.g. `subject`) or a dot separated path into it (e.g. `data.color`)
+func EventSubscriptionAdvancedFilterKey(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	if v == "" {
+		errors = append(errors, fmt.Errorf("%q must not be empty", k))
+		return
+	}
+
+	if strings.HasPrefix(v, ".") || strings.HasSuffix(v, ".") {
+		errors = append(errors, fmt.Errorf("%q must not start or end with a `.`, got %q", k, v))
+		return
+	}
+
+	for _, segment := range strings.Split(v, ".") {
+		if strings.TrimSpace(segment) == "" {
+			errors = append(errors, fmt.Errorf("%q must not contain empty path segments, got %q", k, v))
+			return
+		}
+	}
+
+	return
+}
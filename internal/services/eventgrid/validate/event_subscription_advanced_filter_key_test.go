@@ -0,0 +1,68 @@
+package validate
+
+import "testing"
+
+func TestEventSubscriptionAdvancedFilterKey(t *testing.T) {
+	cases := []struct {
+		Value       string
+		ShouldError bool
+	}{
+		{
+			Value:       "",
+			ShouldError: true,
+		},
+		{
+			Value:       "subject",
+			ShouldError: false,
+		},
+		{
+			Value:       "data.color",
+			ShouldError: false,
+		},
+		{
+			Value:       "data.properties.key1",
+			ShouldError: false,
+		},
+		{
+			Value:       "data.key-with_punctuation",
+			ShouldError: false,
+		},
+		{
+			Value:       ".",
+			ShouldError: true,
+		},
+		{
+			Value:       ".data",
+			ShouldError: true,
+		},
+		{
+			Value:       "data.",
+			ShouldError: true,
+		},
+		{
+			Value:       "data..color",
+			ShouldError: true,
+		},
+		{
+			Value:       "data. .color",
+			ShouldError: true,
+		},
+		{
+			Value:       " ",
+			ShouldError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := EventSubscriptionAdvancedFilterKey(tc.Value, "key")
+
+		hasErrors := len(errors) > 0
+		if hasErrors && !tc.ShouldError {
+			t.Fatalf("Expected no errors but got %q for %q", errors[0], tc.Value)
+		}
+
+		if !hasErrors && tc.ShouldError {
+			t.Fatalf("Expected an error but got none for %q", tc.Value)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package validate
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventSubscriptionExpirationRelativeToNow validates the duration after which an Event Subscription expires, which is
+// a positive Go duration of at least a minute (e.g. `720h`)
+func EventSubscriptionExpirationRelativeToNow(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	duration, err := time.ParseDuration(v)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q cannot be parsed as a duration: %+v", k, err))
+		return
+	}
+
+	if duration < time.Minute {
+		errors = append(errors, fmt.Errorf("%q must be at least 1m, got %q", k, v))
+		return
+	}
+
+	return
+}
@@ -0,0 +1,60 @@
+package validate
+
+import "testing"
+
+func TestEventSubscriptionExpirationRelativeToNow(t *testing.T) {
+	cases := []struct {
+		Value       string
+		ShouldError bool
+	}{
+		{
+			Value:       "",
+			ShouldError: true,
+		},
+		{
+			Value:       "720",
+			ShouldError: true,
+		},
+		{
+			Value:       "30d",
+			ShouldError: true,
+		},
+		{
+			Value:       "-720h",
+			ShouldError: true,
+		},
+		{
+			Value:       "0s",
+			ShouldError: true,
+		},
+		{
+			Value:       "59s",
+			ShouldError: true,
+		},
+		{
+			Value:       "1m",
+			ShouldError: false,
+		},
+		{
+			Value:       "720h",
+			ShouldError: false,
+		},
+		{
+			Value:       "24h30m",
+			ShouldError: false,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := EventSubscriptionExpirationRelativeToNow(tc.Value, "expiration_relative_to_now")
+
+		hasErrors := len(errors) > 0
+		if hasErrors && !tc.ShouldError {
+			t.Fatalf("Expected no errors but got %q for %q", errors[0], tc.Value)
+		}
+
+		if !hasErrors && tc.ShouldError {
+			t.Fatalf("Expected an error but got none for %q", tc.Value)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EventSubscriptionIncludedEventType validates an entry within `included_event_types`, which the service matches
+// literally - as such a warning is returned for `*` and `All`, which would only ever match an event of that type
+func EventSubscriptionIncludedEventType(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	if strings.TrimSpace(v) == "" {
+		errors = append(errors, fmt.Errorf("%q must not be empty", k))
+		return
+	}
+
+	if trimmed := strings.TrimSpace(v); trimmed == "*" || strings.EqualFold(trimmed, "All") {
+		warnings = append(warnings, fmt.Sprintf("%q is matched literally, so %q will only match events with that type rather than all event types - omit `included_event_types` to receive all event types", k, v))
+	}
+
+	return
+}
@@ -0,0 +1,60 @@
+package validate
+
+import "testing"
+
+func TestEventSubscriptionIncludedEventType(t *testing.T) {
+	cases := []struct {
+		Value       string
+		ShouldError bool
+		ShouldWarn  bool
+	}{
+		{
+			Value:       "",
+			ShouldError: true,
+		},
+		{
+			Value:       "  ",
+			ShouldError: true,
+		},
+		{
+			Value: "Microsoft.Storage.BlobCreated",
+		},
+		{
+			Value: "Microsoft.Resources.ResourceWriteSuccess",
+		},
+		{
+			Value:      "*",
+			ShouldWarn: true,
+		},
+		{
+			Value:      "All",
+			ShouldWarn: true,
+		},
+		{
+			Value:      "all",
+			ShouldWarn: true,
+		},
+	}
+
+	for _, tc := range cases {
+		warnings, errors := EventSubscriptionIncludedEventType(tc.Value, "included_event_types.0")
+
+		hasErrors := len(errors) > 0
+		if hasErrors && !tc.ShouldError {
+			t.Fatalf("Expected no errors but got %q for %q", errors[0], tc.Value)
+		}
+
+		if !hasErrors && tc.ShouldError {
+			t.Fatalf("Expected an error but got none for %q", tc.Value)
+		}
+
+		hasWarnings := len(warnings) > 0
+		if hasWarnings && !tc.ShouldWarn {
+			t.Fatalf("Expected no warnings but got %q for %q", warnings[0], tc.Value)
+		}
+
+		if !hasWarnings && tc.ShouldWarn {
+			t.Fatalf("Expected a warning but got none for %q", tc.Value)
+		}
+	}
+}
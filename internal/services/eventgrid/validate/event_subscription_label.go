@@ -0,0 +1,25 @@
+package validate
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+func EventSubscriptionLabel(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	if v == "" {
+		errors = append(errors, fmt.Errorf("%q must not be empty", k))
+		return
+	}
+
+	if length := utf8.RuneCountInString(v); length > 64 {
+		errors = append(errors, fmt.Errorf("%q must be at most 64 characters long, got %d characters in %q", k, length, v))
+	}
+
+	return
+}
@@ -0,0 +1,43 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEventSubscriptionLabel(t *testing.T) {
+	cases := []struct {
+		Value       string
+		ShouldError bool
+	}{
+		{
+			Value:       "",
+			ShouldError: true,
+		},
+		{
+			Value:       "test-label",
+			ShouldError: false,
+		},
+		{
+			Value:       strings.Repeat("a", 64),
+			ShouldError: false,
+		},
+		{
+			Value:       strings.Repeat("a", 65),
+			ShouldError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := EventSubscriptionLabel(tc.Value, "labels")
+
+		hasErrors := len(errors) > 0
+		if hasErrors && !tc.ShouldError {
+			t.Fatalf("Expected no errors but got %q for %q", errors[0], tc.Value)
+		}
+
+		if !hasErrors && tc.ShouldError {
+			t.Fatalf("Expected an error but got none for %q", tc.Value)
+		}
+	}
+}
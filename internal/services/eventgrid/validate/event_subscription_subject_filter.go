@@ -0,0 +1,23 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EventSubscriptionSubjectFilter validates the prefix/suffix of a subject filter, which is matched literally by the
+// service - as such glob characters are rejected, since they'd only ever match a subject containing them
+func EventSubscriptionSubjectFilter(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	if strings.ContainsAny(v, "*?") {
+		errors = append(errors, fmt.Errorf("%q is matched literally so cannot contain the wildcard characters `*` or `?`, got %q - an `advanced_filter` (such as `string_contains`) can be used to match subjects by pattern", k, v))
+		return
+	}
+
+	return
+}
@@ -0,0 +1,48 @@
+package validate
+
+import "testing"
+
+func TestEventSubscriptionSubjectFilter(t *testing.T) {
+	cases := []struct {
+		Value       string
+		ShouldError bool
+	}{
+		{
+			Value:       "",
+			ShouldError: false,
+		},
+		{
+			Value:       "/blobServices/default/containers/images/",
+			ShouldError: false,
+		},
+		{
+			Value:       ".jpg",
+			ShouldError: false,
+		},
+		{
+			Value:       "/blobServices/default/containers/*/",
+			ShouldError: true,
+		},
+		{
+			Value:       "*.jpg",
+			ShouldError: true,
+		},
+		{
+			Value:       "image?.jpg",
+			ShouldError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := EventSubscriptionSubjectFilter(tc.Value, "subject_begins_with")
+
+		hasErrors := len(errors) > 0
+		if hasErrors && !tc.ShouldError {
+			t.Fatalf("Expected no errors but got %q for %q", errors[0], tc.Value)
+		}
+
+		if !hasErrors && tc.ShouldError {
+			t.Fatalf("Expected an error but got none for %q", tc.Value)
+		}
+	}
+}
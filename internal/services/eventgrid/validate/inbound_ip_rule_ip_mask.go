@@ -0,0 +1,30 @@
+package validate
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// InboundIPRuleIPMask validates that the value is an IPv4 address (e.g. `10.0.0.1`) or an IPv4 CIDR (e.g. `10.0.0.0/24`)
+func InboundIPRuleIPMask(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	if strings.Contains(v, "/") {
+		ip, _, err := net.ParseCIDR(v)
+		if err != nil || ip.To4() == nil {
+			errors = append(errors, fmt.Errorf("expected %q to be an IPv4 address or CIDR, got %q", k, v))
+		}
+		return
+	}
+
+	if ip := net.ParseIP(v); ip == nil || ip.To4() == nil {
+		errors = append(errors, fmt.Errorf("expected %q to be an IPv4 address or CIDR, got %q", k, v))
+	}
+
+	return
+}
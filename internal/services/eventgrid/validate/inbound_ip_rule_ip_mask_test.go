@@ -0,0 +1,60 @@
+package validate
+
+import "testing"
+
+func TestInboundIPRuleIPMask(t *testing.T) {
+	cases := []struct {
+		Value       string
+		ShouldError bool
+	}{
+		{
+			Value:       "",
+			ShouldError: true,
+		},
+		{
+			Value:       "10.0.0.1",
+			ShouldError: false,
+		},
+		{
+			Value:       "10.0.0.0/24",
+			ShouldError: false,
+		},
+		{
+			Value:       "10.0.0.0/33",
+			ShouldError: true,
+		},
+		{
+			Value:       "10.0.0.256",
+			ShouldError: true,
+		},
+		{
+			Value:       "10.0.0.0/",
+			ShouldError: true,
+		},
+		{
+			Value:       "2001:db8::1",
+			ShouldError: true,
+		},
+		{
+			Value:       "2001:db8::/32",
+			ShouldError: true,
+		},
+		{
+			Value:       "not-an-ip",
+			ShouldError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := InboundIPRuleIPMask(tc.Value, "ip_mask")
+
+		hasErrors := len(errors) > 0
+		if hasErrors && !tc.ShouldError {
+			t.Fatalf("Expected no errors but got %q for %q", errors[0], tc.Value)
+		}
+
+		if !hasErrors && tc.ShouldError {
+			t.Fatalf("Expected an error but got none for %q", tc.Value)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SystemTopicNameOrID validates either the name of an EventGrid System Topic or its full Resource ID
+func SystemTopicNameOrID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	if strings.TrimSpace(v) == "" {
+		errors = append(errors, fmt.Errorf("%q must not be empty", key))
+		return
+	}
+
+	if strings.Contains(v, "/") {
+		return SystemTopicID(input, key)
+	}
+
+	return
+}
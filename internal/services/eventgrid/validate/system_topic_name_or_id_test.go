@@ -0,0 +1,52 @@
+package validate
+
+import "testing"
+
+func TestSystemTopicNameOrID(t *testing.T) {
+	cases := []struct {
+		Value       string
+		ShouldError bool
+	}{
+		{
+			Value:       "",
+			ShouldError: true,
+		},
+		{
+			Value:       " ",
+			ShouldError: true,
+		},
+		{
+			Value:       "systemTopic1",
+			ShouldError: false,
+		},
+		{
+			Value:       "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.EventGrid/systemTopics/systemTopic1",
+			ShouldError: false,
+		},
+		{
+			Value:       "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.EventGrid/topics/topic1",
+			ShouldError: true,
+		},
+		{
+			Value:       "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1",
+			ShouldError: true,
+		},
+		{
+			Value:       "resGroup1/systemTopic1",
+			ShouldError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := SystemTopicNameOrID(tc.Value, "system_topic")
+
+		hasErrors := len(errors) > 0
+		if hasErrors && !tc.ShouldError {
+			t.Fatalf("Expected no errors but got %q for %q", errors[0], tc.Value)
+		}
+
+		if !hasErrors && tc.ShouldError {
+			t.Fatalf("Expected an error but got none for %q", tc.Value)
+		}
+	}
+}